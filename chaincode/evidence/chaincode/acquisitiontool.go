@@ -0,0 +1,57 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// requireAcquisitionToolKey toggles whether CreateEvidence requires
+// AcquisitionTool and AcquisitionToolVersion to be non-empty. Off by
+// default for backward compatibility with callers that predate this field.
+const requireAcquisitionToolKey = "POLICY_REQUIRE_ACQUISITION_TOOL"
+
+// SetRequireAcquisitionTool enables or disables rejecting CreateEvidence
+// calls that don't name the imaging tool and version used.
+func (s *SmartContract) SetRequireAcquisitionTool(ctx contractapi.TransactionContextInterface, required bool) error {
+	value := "false"
+	if required {
+		value = "true"
+	}
+	return ctx.GetStub().PutState(requireAcquisitionToolKey, []byte(value))
+}
+
+func (s *SmartContract) requiresAcquisitionTool(ctx contractapi.TransactionContextInterface) (bool, error) {
+	bytes, err := ctx.GetStub().GetState(requireAcquisitionToolKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to read acquisition tool policy: %v", err)
+	}
+	return string(bytes) == "true", nil
+}
+
+// validateAcquisitionTool rejects an empty tool or version once
+// SetRequireAcquisitionTool(true) is set.
+func (s *SmartContract) validateAcquisitionTool(ctx contractapi.TransactionContextInterface, tool, version string) error {
+	required, err := s.requiresAcquisitionTool(ctx)
+	if err != nil {
+		return err
+	}
+	if !required {
+		return nil
+	}
+	if tool == "" || version == "" {
+		return fmt.Errorf("acquisitionTool and acquisitionToolVersion are required")
+	}
+	return nil
+}
+
+// QueryEvidencesByTool returns every evidence record acquired with the
+// named tool, so an audit can identify everything touched by a tool later
+// found to have a defect.
+func (s *SmartContract) QueryEvidencesByTool(ctx contractapi.TransactionContextInterface, tool string) ([]*Evidence, error) {
+	queryString, err := buildSelectorQuery(map[string]interface{}{"docType": "evidence", "acquisitionTool": tool})
+	if err != nil {
+		return nil, err
+	}
+	return getQueryResultForQueryString(ctx, queryString)
+}
@@ -0,0 +1,119 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// AddAnnotation attaches a free-text note to an evidence item, recording an
+// ANNOTATE custody event. Annotations are append-only; use WithdrawAnnotation
+// to retract one rather than editing or removing it.
+func (s *SmartContract) AddAnnotation(ctx contractapi.TransactionContextInterface, caseID, evidenceID, author, text string) error {
+	if text == "" {
+		return fmt.Errorf("text is required")
+	}
+	if err := validateID("author", author); err != nil {
+		return err
+	}
+
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+
+	event, err := newCustodyEvent(ctx, EventAnnotate, author, "", "", "")
+	if err != nil {
+		return err
+	}
+
+	e.Annotations = append(e.Annotations, Annotation{
+		Text:      text,
+		Author:    author,
+		CreatedAt: event.Timestamp,
+	})
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return putEvidence(ctx, e)
+}
+
+// withdrawnAnnotationCollection is the private data collection a withdrawn
+// annotation's original text is moved into, the same "retained but out of
+// public view" mechanism redaction.go uses for metadata fields. Its actual
+// membership policy lives in the channel's collections config, outside this
+// chaincode.
+const withdrawnAnnotationCollection = "withdrawnAnnotationCollection"
+
+func withdrawnAnnotationKey(caseID, evidenceID string, annotationIndex int) string {
+	return fmt.Sprintf("WITHDRAWN_ANNOTATION_%s_%s_%d", caseID, evidenceID, annotationIndex)
+}
+
+// WithdrawAnnotation retracts the annotation at annotationIndex: its Text is
+// replaced with a withdrawal notice in the public record, the original is
+// moved into withdrawnAnnotationCollection, and an ANNOTATE custody event
+// records that a withdrawal happened. Only the annotation's own author or a
+// supervisor may withdraw it.
+func (s *SmartContract) WithdrawAnnotation(ctx contractapi.TransactionContextInterface, caseID, evidenceID string, annotationIndex int, reason string) error {
+	if reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if annotationIndex < 0 || annotationIndex >= len(e.Annotations) {
+		return fmt.Errorf("annotation index %d out of range for evidence %s (%d annotations)", annotationIndex, evidenceID, len(e.Annotations))
+	}
+	annotation := &e.Annotations[annotationIndex]
+	if annotation.Withdrawn {
+		return fmt.Errorf("annotation %d on evidence %s is already withdrawn", annotationIndex, evidenceID)
+	}
+
+	caller, err := callerID(ctx)
+	if err != nil {
+		return err
+	}
+	if caller != annotation.Author {
+		if err := requireSupervisor(ctx); err != nil {
+			return fmt.Errorf("only the annotation's author (%s) or a supervisor may withdraw it", annotation.Author)
+		}
+	}
+
+	event, err := newCustodyEvent(ctx, EventAnnotate, caller, "", "", fmt.Sprintf("withdrew annotation %d: %s", annotationIndex, reason))
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.GetStub().PutPrivateData(withdrawnAnnotationCollection, withdrawnAnnotationKey(caseID, evidenceID, annotationIndex), []byte(annotation.Text)); err != nil {
+		return fmt.Errorf("failed to store withdrawn annotation text: %v", err)
+	}
+
+	annotation.Text = "[withdrawn]"
+	annotation.Withdrawn = true
+	annotation.WithdrawnBy = caller
+	annotation.WithdrawnReason = reason
+	annotation.WithdrawnAt = event.Timestamp
+
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return putEvidence(ctx, e)
+}
+
+// GetWithdrawnAnnotationText returns the original text of a withdrawn
+// annotation, for supervisors who need to review what was retracted and why.
+func (s *SmartContract) GetWithdrawnAnnotationText(ctx contractapi.TransactionContextInterface, caseID, evidenceID string, annotationIndex int) (string, error) {
+	if err := requireSupervisor(ctx); err != nil {
+		return "", err
+	}
+	original, err := ctx.GetStub().GetPrivateData(withdrawnAnnotationCollection, withdrawnAnnotationKey(caseID, evidenceID, annotationIndex))
+	if err != nil {
+		return "", fmt.Errorf("failed to read withdrawn annotation text: %v", err)
+	}
+	if original == nil {
+		return "", fmt.Errorf("no withdrawn annotation found at index %d for evidence %s", annotationIndex, evidenceID)
+	}
+	return string(original), nil
+}
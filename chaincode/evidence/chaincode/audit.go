@@ -0,0 +1,117 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// ConsistencyIssue reports a mismatch AuditInvalidationConsistency found
+// between an InvalidationRecord and the evidence it refers to.
+type ConsistencyIssue struct {
+	CaseID     string `json:"caseID"`
+	EvidenceID string `json:"evidenceID"`
+	TxID       string `json:"txID"`
+	Issue      string `json:"issue"`
+}
+
+// AuditInvalidationConsistency ranges over every InvalidationRecord and
+// cross-checks it against the evidence it refers to, reporting any case
+// where the evidence is missing or not actually in the INVALIDATED status.
+// It's an integrity self-test: invalidation records and evidence status are
+// updated together by InvalidateEvidence, but live under separate keys, so
+// a bug or a bad migration could let them drift apart undetected.
+func (s *SmartContract) AuditInvalidationConsistency(ctx contractapi.TransactionContextInterface) ([]ConsistencyIssue, error) {
+	iterator, err := ctx.GetStub().GetStateByRange(invalidationKeyPrefix, invalidationKeyPrefix+"￿")
+	if err != nil {
+		return nil, fmt.Errorf("failed to range over invalidation records: %v", err)
+	}
+	defer iterator.Close()
+
+	var issues []ConsistencyIssue
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		var record InvalidationRecord
+		if err := json.Unmarshal(item.Value, &record); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal invalidation record %s: %v", item.Key, err)
+		}
+
+		e, err := getEvidence(ctx, record.CaseID, record.EvidenceID)
+		if err != nil {
+			if isNotFoundError(err) {
+				issues = append(issues, ConsistencyIssue{
+					CaseID:     record.CaseID,
+					EvidenceID: record.EvidenceID,
+					TxID:       record.TxID,
+					Issue:      "invalidation record exists but evidence record is missing",
+				})
+				continue
+			}
+			return nil, err
+		}
+		if e.Status != StatusInvalidated {
+			issues = append(issues, ConsistencyIssue{
+				CaseID:     record.CaseID,
+				EvidenceID: record.EvidenceID,
+				TxID:       record.TxID,
+				Issue:      fmt.Sprintf("invalidation record exists but evidence status is %s, not %s", e.Status, StatusInvalidated),
+			})
+		}
+	}
+	return issues, nil
+}
+
+// RebuildInvalidationIndex regenerates the INVALIDATION_ index from
+// authoritative evidence state, for recovering from index drift (e.g. after
+// a restore that didn't carry the index forward). For every INVALIDATED
+// evidence record, it finds that record's most recent INVALIDATE event and
+// rewrites the corresponding InvalidationRecord from it. Supervisor-only.
+// Returns the number of index entries rebuilt.
+func (s *SmartContract) RebuildInvalidationIndex(ctx contractapi.TransactionContextInterface) (int, error) {
+	if err := requireSupervisor(ctx); err != nil {
+		return 0, err
+	}
+
+	iterator, err := ctx.GetStub().GetStateByRange(evidenceKeyPrefix, evidenceKeyPrefix+"￿")
+	if err != nil {
+		return 0, fmt.Errorf("failed to range-scan evidence: %v", err)
+	}
+	defer iterator.Close()
+
+	rebuilt := 0
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		if err != nil {
+			return rebuilt, err
+		}
+		var e Evidence
+		if err := json.Unmarshal(item.Value, &e); err != nil {
+			return rebuilt, fmt.Errorf("failed to unmarshal evidence %s: %v", item.Key, err)
+		}
+		if e.Status != StatusInvalidated {
+			continue
+		}
+
+		var lastInvalidate *CustodyEvent
+		for i := len(e.Events) - 1; i >= 0; i-- {
+			if e.Events[i].EventType == EventInvalidate {
+				lastInvalidate = &e.Events[i]
+				break
+			}
+		}
+		if lastInvalidate == nil {
+			return rebuilt, fmt.Errorf("evidence %s is INVALIDATED but has no INVALIDATE event in its history", e.EvidenceID)
+		}
+
+		if err := writeInvalidationRecord(ctx, e.CaseID, e.EvidenceID, lastInvalidate.TxID, lastInvalidate.Timestamp, lastInvalidate.ReasonCode, lastInvalidate.Reason); err != nil {
+			return rebuilt, err
+		}
+		rebuilt++
+	}
+
+	return rebuilt, nil
+}
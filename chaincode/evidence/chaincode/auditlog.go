@@ -0,0 +1,92 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// AuditLogEntry is one custody event flattened out of its parent evidence
+// record, for compliance reporting that cuts across cases.
+type AuditLogEntry struct {
+	CaseID     string `json:"caseID"`
+	EvidenceID string `json:"evidenceID"`
+	CustodyEvent
+}
+
+// AuditLogPage is one page of a GetAuditLog scan, carrying the CouchDB
+// bookmark needed to fetch the next page.
+type AuditLogPage struct {
+	Entries             []AuditLogEntry `json:"entries"`
+	Bookmark            string          `json:"bookmark"`
+	FetchedRecordsCount int32           `json:"fetchedRecordsCount"`
+}
+
+// GetAuditLog scans evidence records page by page (CouchDB bookmark
+// pagination) and returns every custody event whose timestamp falls within
+// [startTime, endTime), flattened with its caseID/evidenceID and sorted
+// chronologically, for the compliance SIEM feed.
+//
+// Because filtering happens after the page is fetched, a page's entry count
+// can be smaller than pageSize even when more matching events remain;
+// callers should keep paging with the returned bookmark until it comes back
+// empty.
+func (s *SmartContract) GetAuditLog(ctx contractapi.TransactionContextInterface, startTime, endTime string, pageSize int32, bookmark string) (*AuditLogPage, error) {
+	if startTime == "" || endTime == "" {
+		return nil, fmt.Errorf("startTime and endTime are required")
+	}
+	startTime, err := normalizeTimestamp(startTime)
+	if err != nil {
+		return nil, err
+	}
+	endTime, err = normalizeTimestamp(endTime)
+	if err != nil {
+		return nil, err
+	}
+	if startTime > endTime {
+		return nil, fmt.Errorf("startTime must not be after endTime")
+	}
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("pageSize must be positive")
+	}
+
+	queryString := `{"selector":{"docType":"evidence"}}`
+	iterator, metadata, err := ctx.GetStub().GetQueryResultWithPagination(queryString, pageSize, bookmark)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute paginated audit query: %v", err)
+	}
+	defer iterator.Close()
+
+	var entries []AuditLogEntry
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		var e Evidence
+		if err := json.Unmarshal(item.Value, &e); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal evidence from audit query result: %v", err)
+		}
+		for _, event := range e.Events {
+			if event.Timestamp < startTime || event.Timestamp >= endTime {
+				continue
+			}
+			entries = append(entries, AuditLogEntry{
+				CaseID:       e.CaseID,
+				EvidenceID:   e.EvidenceID,
+				CustodyEvent: event,
+			})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Timestamp < entries[j].Timestamp
+	})
+
+	return &AuditLogPage{
+		Entries:             entries,
+		Bookmark:            metadata.Bookmark,
+		FetchedRecordsCount: metadata.FetchedRecordsCount,
+	}, nil
+}
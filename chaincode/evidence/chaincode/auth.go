@@ -0,0 +1,34 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// supervisorRoleValue is the expected value of the "role" client certificate
+// attribute for supervisor-restricted operations.
+const supervisorRoleValue = "supervisor"
+
+// requireSupervisor rejects the call unless the submitting identity carries
+// the supervisor role attribute on its enrollment certificate.
+func requireSupervisor(ctx contractapi.TransactionContextInterface) error {
+	role, ok, err := ctx.GetClientIdentity().GetAttributeValue("role")
+	if err != nil {
+		return fmt.Errorf("failed to read caller role attribute: %v", err)
+	}
+	if !ok || role != supervisorRoleValue {
+		return fmt.Errorf("this operation is restricted to the supervisor role")
+	}
+	return nil
+}
+
+// callerID returns a stable identifier for the submitting identity, used to
+// attribute actions performed by administrative functions.
+func callerID(ctx contractapi.TransactionContextInterface) (string, error) {
+	id, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		return "", fmt.Errorf("failed to read caller identity: %v", err)
+	}
+	return id, nil
+}
@@ -0,0 +1,32 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// backdatePolicyKey stores how CreateEvidence reacts when a new record's
+// timestamp predates its case's OpenedAt: "reject" the write, or "warn" and
+// let it through flagged. Defaults to "reject" when unset.
+const backdatePolicyKey = "POLICY_BACKDATE_BEHAVIOR"
+
+// SetBackdatePolicy configures CreateEvidence's reaction to evidence dated
+// before its case was opened. mode must be "reject" or "warn".
+func (s *SmartContract) SetBackdatePolicy(ctx contractapi.TransactionContextInterface, mode string) error {
+	if mode != "reject" && mode != "warn" {
+		return fmt.Errorf("mode must be 'reject' or 'warn', got %q", mode)
+	}
+	return ctx.GetStub().PutState(backdatePolicyKey, []byte(mode))
+}
+
+func backdatePolicy(ctx contractapi.TransactionContextInterface) (string, error) {
+	bytes, err := ctx.GetStub().GetState(backdatePolicyKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to read backdate policy: %v", err)
+	}
+	if len(bytes) == 0 {
+		return "reject", nil
+	}
+	return string(bytes), nil
+}
@@ -0,0 +1,80 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// BatchVerificationRequest is one item in VerifyEvidenceBatch's input.
+type BatchVerificationRequest struct {
+	CaseID       string `json:"caseID"`
+	EvidenceID   string `json:"evidenceID"`
+	ProvidedHash string `json:"providedHash"`
+}
+
+// BatchVerificationResult is one item's outcome. Error is set instead of
+// Matches being meaningful when the item itself couldn't be verified (bad
+// ID, malformed hash, etc.), distinct from Matches being false because the
+// hash didn't match.
+type BatchVerificationResult struct {
+	CaseID     string `json:"caseID"`
+	EvidenceID string `json:"evidenceID"`
+	Matches    bool   `json:"matches"`
+	Error      string `json:"error,omitempty"`
+}
+
+// BatchVerifyResult summarizes a VerifyEvidenceBatch run.
+type BatchVerifyResult struct {
+	Results         []BatchVerificationResult `json:"results"`
+	MatchedCount    int                       `json:"matchedCount"`
+	MismatchedCount int                       `json:"mismatchedCount"`
+	ErrorCount      int                       `json:"errorCount"`
+}
+
+// VerifyEvidenceBatch runs VerifyEvidenceIntegrity for every item in
+// verificationsJSON (a JSON array of BatchVerificationRequest), so the
+// nightly integrity sweep can check hundreds of items in one transaction.
+// One item failing (bad ID, malformed hash) doesn't abort the rest; each
+// item's outcome is reported independently. Emits a single BatchVerified
+// summary event alongside each item's own VERIFY event.
+func (s *SmartContract) VerifyEvidenceBatch(ctx contractapi.TransactionContextInterface, verificationsJSON string) (*BatchVerifyResult, error) {
+	var requests []BatchVerificationRequest
+	if err := json.Unmarshal([]byte(verificationsJSON), &requests); err != nil {
+		return nil, fmt.Errorf("invalid verifications JSON: %v", err)
+	}
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("verifications must contain at least one item")
+	}
+
+	result := &BatchVerifyResult{}
+	for _, req := range requests {
+		matches, err := s.VerifyEvidenceIntegrity(ctx, req.CaseID, req.EvidenceID, req.ProvidedHash, "", "")
+		item := BatchVerificationResult{CaseID: req.CaseID, EvidenceID: req.EvidenceID, Matches: matches}
+		switch {
+		case err != nil:
+			item.Error = err.Error()
+			result.ErrorCount++
+		case matches:
+			result.MatchedCount++
+		default:
+			result.MismatchedCount++
+		}
+		result.Results = append(result.Results, item)
+	}
+
+	summary, err := json.Marshal(map[string]int{
+		"total":      len(requests),
+		"matched":    result.MatchedCount,
+		"mismatched": result.MismatchedCount,
+		"errors":     result.ErrorCount,
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to marshal batch summary: %v", err)
+	}
+	if err := ctx.GetStub().SetEvent("BatchVerified", summary); err != nil {
+		return result, fmt.Errorf("failed to emit BatchVerified event: %v", err)
+	}
+	return result, nil
+}
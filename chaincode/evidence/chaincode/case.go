@@ -0,0 +1,110 @@
+package chaincode
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+const caseKeyPrefix = "CASE_"
+
+// Case is the lightweight, case-level record that tracks case-wide
+// aggregates (such as the manifest hash) alongside the evidence it contains.
+type Case struct {
+	DocType      string `json:"docType"`
+	CaseID       string `json:"caseID"`
+	ManifestHash string `json:"manifestHash,omitempty"`
+	UpdatedAt    string `json:"updatedAt,omitempty"`
+
+	// OpenedAt is the case's opening date, set via SetCaseOpenedAt. When
+	// present, CreateEvidence checks new evidence's timestamp against it
+	// (see backdatePolicyKey) to catch backdating errors.
+	OpenedAt string `json:"openedAt,omitempty"`
+}
+
+// SetCaseOpenedAt records when a case was opened, so CreateEvidence can
+// catch evidence mistakenly dated before the case existed.
+func (s *SmartContract) SetCaseOpenedAt(ctx contractapi.TransactionContextInterface, caseID, openedAt string) error {
+	normalized, err := normalizeTimestamp(openedAt)
+	if err != nil {
+		return err
+	}
+	c, err := getOrCreateCase(ctx, caseID)
+	if err != nil {
+		return err
+	}
+	c.OpenedAt = normalized
+	return putCase(ctx, c)
+}
+
+func caseKey(caseID string) string {
+	return caseKeyPrefix + caseID
+}
+
+// getOrCreateCase returns the Case record for caseID, creating a bare one if
+// it doesn't exist yet. Case records are created implicitly by aggregate
+// operations rather than requiring a separate "open case" step today.
+func getOrCreateCase(ctx contractapi.TransactionContextInterface, caseID string) (*Case, error) {
+	bytes, err := ctx.GetStub().GetState(caseKey(caseID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read case %s: %v", caseID, err)
+	}
+	if bytes == nil {
+		return &Case{DocType: "case", CaseID: caseID}, nil
+	}
+	var c Case
+	if err := json.Unmarshal(bytes, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal case %s: %v", caseID, err)
+	}
+	return &c, nil
+}
+
+func putCase(ctx contractapi.TransactionContextInterface, c *Case) error {
+	bytes, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal case: %v", err)
+	}
+	return ctx.GetStub().PutState(caseKey(c.CaseID), bytes)
+}
+
+// ComputeCaseManifestHash returns a single SHA-256 value over every
+// evidence item in the case (hash + updatedAt, in deterministic
+// EvidenceID order), giving prosecutors one number to cite for the whole
+// case's integrity. The result is persisted on the Case record.
+func (s *SmartContract) ComputeCaseManifestHash(ctx contractapi.TransactionContextInterface, caseID string) (string, error) {
+	evidences, err := s.QueryEvidencesByCase(ctx, caseID)
+	if err != nil {
+		return "", err
+	}
+
+	sort.Slice(evidences, func(i, j int) bool {
+		return evidences[i].EvidenceID < evidences[j].EvidenceID
+	})
+
+	hasher := sha256.New()
+	for _, e := range evidences {
+		hasher.Write([]byte(e.Hash))
+		hasher.Write([]byte(e.UpdatedAt))
+	}
+	manifestHash := hex.EncodeToString(hasher.Sum(nil))
+
+	c, err := getOrCreateCase(ctx, caseID)
+	if err != nil {
+		return "", err
+	}
+	ts, err := getTxTimestamp(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.ManifestHash = manifestHash
+	c.UpdatedAt = ts
+	if err := putCase(ctx, c); err != nil {
+		return "", err
+	}
+
+	return manifestHash, nil
+}
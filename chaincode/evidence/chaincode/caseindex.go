@@ -0,0 +1,92 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+const caseIndexKeyPrefix = "CASEINDEX_"
+
+// CaseEvidenceIndex is a standalone record of every evidence ID registered
+// under a case, maintained alongside the CouchDB-only rich queries so that
+// case listing also works on LevelDB peers (see ListCaseEvidenceIDs).
+type CaseEvidenceIndex struct {
+	DocType     string   `json:"docType"`
+	CaseID      string   `json:"caseID"`
+	EvidenceIDs []string `json:"evidenceIDs"`
+}
+
+func caseIndexKey(caseID string) string {
+	return caseIndexKeyPrefix + caseID
+}
+
+func getOrCreateCaseIndex(ctx contractapi.TransactionContextInterface, caseID string) (*CaseEvidenceIndex, error) {
+	bytes, err := ctx.GetStub().GetState(caseIndexKey(caseID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read case index %s: %v", caseID, err)
+	}
+	if bytes == nil {
+		return &CaseEvidenceIndex{DocType: "caseIndex", CaseID: caseID}, nil
+	}
+	var idx CaseEvidenceIndex
+	if err := json.Unmarshal(bytes, &idx); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal case index %s: %v", caseID, err)
+	}
+	return &idx, nil
+}
+
+func putCaseIndex(ctx contractapi.TransactionContextInterface, idx *CaseEvidenceIndex) error {
+	bytes, err := json.Marshal(idx)
+	if err != nil {
+		return fmt.Errorf("failed to marshal case index: %v", err)
+	}
+	return ctx.GetStub().PutState(caseIndexKey(idx.CaseID), bytes)
+}
+
+// addToCaseIndex records evidenceID as belonging to caseID, called from
+// CreateEvidence. It is a no-op if the ID is already present, so it's safe
+// to call more than once for the same evidence.
+func addToCaseIndex(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) error {
+	idx, err := getOrCreateCaseIndex(ctx, caseID)
+	if err != nil {
+		return err
+	}
+	for _, id := range idx.EvidenceIDs {
+		if id == evidenceID {
+			return nil
+		}
+	}
+	idx.EvidenceIDs = append(idx.EvidenceIDs, evidenceID)
+	return putCaseIndex(ctx, idx)
+}
+
+// ListCaseEvidenceIDs returns every evidence ID registered under caseID,
+// reading the CASEINDEX_ record directly via GetState rather than a
+// CouchDB selector, so it works on LevelDB-backed peers.
+func (s *SmartContract) ListCaseEvidenceIDs(ctx contractapi.TransactionContextInterface, caseID string) ([]string, error) {
+	idx, err := getOrCreateCaseIndex(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+	return idx.EvidenceIDs, nil
+}
+
+// RebuildCaseIndex regenerates the CASEINDEX_ record for caseID from the
+// evidence records themselves, for recovery if the index was never built
+// (evidence created before this index existed) or has drifted.
+func (s *SmartContract) RebuildCaseIndex(ctx contractapi.TransactionContextInterface, caseID string) error {
+	evidences, err := s.QueryEvidencesByCase(ctx, caseID)
+	if err != nil {
+		return err
+	}
+	ids := make([]string, 0, len(evidences))
+	for _, e := range evidences {
+		ids = append(ids, e.EvidenceID)
+	}
+	sort.Strings(ids)
+	idx := &CaseEvidenceIndex{DocType: "caseIndex", CaseID: caseID, EvidenceIDs: ids}
+	return putCaseIndex(ctx, idx)
+}
@@ -0,0 +1,69 @@
+package chaincode
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// enforceCertValidityKey toggles whether a submitting identity's enrollment
+// certificate must be within its validity window (NotBefore/NotAfter) for
+// its actions to be recorded. Off by default for backward compatibility
+// with deployments whose CAs don't rotate certs tightly around the
+// validity window.
+const enforceCertValidityKey = "POLICY_ENFORCE_CERT_VALIDITY"
+
+// SetEnforceCertValidity enables or disables rejecting actions submitted
+// with an expired or not-yet-valid certificate, strengthening the legal
+// defensibility of the resulting custody trail.
+func (s *SmartContract) SetEnforceCertValidity(ctx contractapi.TransactionContextInterface, enabled bool) error {
+	value := "false"
+	if enabled {
+		value = "true"
+	}
+	return ctx.GetStub().PutState(enforceCertValidityKey, []byte(value))
+}
+
+func enforceCertValidityEnabled(ctx contractapi.TransactionContextInterface) (bool, error) {
+	bytes, err := ctx.GetStub().GetState(enforceCertValidityKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to read cert validity policy: %v", err)
+	}
+	return string(bytes) == "true", nil
+}
+
+// checkCertValidity rejects the call if SetEnforceCertValidity is on and
+// the submitting identity's certificate is outside its NotBefore/NotAfter
+// window as of the transaction timestamp.
+func checkCertValidity(ctx contractapi.TransactionContextInterface) error {
+	enabled, err := enforceCertValidityEnabled(ctx)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	cert, err := ctx.GetClientIdentity().GetX509Certificate()
+	if err != nil {
+		return fmt.Errorf("failed to read caller certificate: %v", err)
+	}
+
+	ts, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+	now, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return err
+	}
+
+	if now.Before(cert.NotBefore) {
+		return fmt.Errorf("caller's certificate is not yet valid (valid from %s)", cert.NotBefore.Format(time.RFC3339))
+	}
+	if now.After(cert.NotAfter) {
+		return fmt.Errorf("caller's certificate has expired (valid until %s)", cert.NotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Evidence sensitivity tiers. Higher tiers require a matching or higher
+// caller clearance for operations guarded by requireClearance.
+const (
+	ClassificationRoutine    = "ROUTINE"
+	ClassificationSensitive  = "SENSITIVE"
+	ClassificationClassified = "CLASSIFIED"
+)
+
+// classificationRank orders the sensitivity tiers so clearance can be
+// compared numerically instead of by exhaustive case matching.
+var classificationRank = map[string]int{
+	ClassificationRoutine:    0,
+	ClassificationSensitive:  1,
+	ClassificationClassified: 2,
+}
+
+// clearanceAttribute is the client certificate attribute carrying the
+// caller's clearance tier.
+const clearanceAttribute = "clearance"
+
+// validateClassification defaults an empty classification to ROUTINE and
+// rejects anything outside the configured tier set.
+func validateClassification(classification string) (string, error) {
+	if classification == "" {
+		return ClassificationRoutine, nil
+	}
+	if _, ok := classificationRank[classification]; !ok {
+		return "", fmt.Errorf("classification must be one of ROUTINE, SENSITIVE, CLASSIFIED, got %q", classification)
+	}
+	return classification, nil
+}
+
+// callerClearance reads the caller's clearance cert attribute, defaulting
+// to the lowest tier when the attribute is absent.
+func callerClearance(ctx contractapi.TransactionContextInterface) (string, error) {
+	clearance, ok, err := ctx.GetClientIdentity().GetAttributeValue(clearanceAttribute)
+	if err != nil {
+		return "", fmt.Errorf("failed to read caller clearance attribute: %v", err)
+	}
+	if !ok || clearance == "" {
+		return ClassificationRoutine, nil
+	}
+	return clearance, nil
+}
+
+// requireClearance rejects the call unless the caller's clearance
+// attribute is at or above classification's tier.
+func requireClearance(ctx contractapi.TransactionContextInterface, classification string) error {
+	required, ok := classificationRank[classification]
+	if !ok {
+		required = classificationRank[ClassificationRoutine]
+	}
+	clearance, err := callerClearance(ctx)
+	if err != nil {
+		return err
+	}
+	have, ok := classificationRank[clearance]
+	if !ok {
+		return fmt.Errorf("caller clearance %q is not a recognized tier", clearance)
+	}
+	if have < required {
+		return fmt.Errorf("caller clearance %q is insufficient for %s evidence", clearance, classification)
+	}
+	return nil
+}
+
+// ReclassifyEvidence changes an evidence item's sensitivity tier.
+// Supervisor-only, and records a RECLASSIFY custody event plus an
+// EvidenceReclassified chaincode event.
+func (s *SmartContract) ReclassifyEvidence(ctx contractapi.TransactionContextInterface, caseID, evidenceID, classification string) error {
+	if err := requireSupervisor(ctx); err != nil {
+		return err
+	}
+	classification, err := validateClassification(classification)
+	if err != nil {
+		return err
+	}
+
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	previous := e.Classification
+
+	event, err := newCustodyEvent(ctx, EventReclassify, e.CurrentOwner, "", "", fmt.Sprintf("reclassified from %s to %s", previous, classification))
+	if err != nil {
+		return err
+	}
+	e.Classification = classification
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	if err := putEvidence(ctx, e); err != nil {
+		return err
+	}
+	return ctx.GetStub().SetEvent("EvidenceReclassified", []byte(fmt.Sprintf(`{"caseID":%q,"evidenceID":%q,"classification":%q}`, caseID, evidenceID, classification)))
+}
@@ -0,0 +1,68 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// ContinuityResult reports whether an evidence item's ARCHIVE/REACTIVATE
+// pairing is balanced and its event timestamps are monotonic, plus any
+// specific anomalies found.
+type ContinuityResult struct {
+	Valid           bool     `json:"valid"`
+	ArchiveCount    int      `json:"archiveCount"`
+	ReactivateCount int      `json:"reactivateCount"`
+	Anomalies       []string `json:"anomalies,omitempty"`
+}
+
+// VerifyColdChainContinuity checks that evidenceID's ARCHIVE and REACTIVATE
+// events alternate correctly (every ARCHIVE is followed by a REACTIVATE,
+// except possibly the last if the item is still archived), and that every
+// event's timestamp is no earlier than the one before it. This validates
+// the hot/cold lifecycle integrity that's central to the system: a missing
+// or out-of-order pairing would mean an archive window was tampered with or
+// an event was lost.
+func (s *SmartContract) VerifyColdChainContinuity(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) (*ContinuityResult, error) {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ContinuityResult{Valid: true}
+
+	for i := 1; i < len(e.Events); i++ {
+		if e.Events[i].Timestamp < e.Events[i-1].Timestamp {
+			result.Anomalies = append(result.Anomalies, fmt.Sprintf("event %d (%s at %s) precedes event %d (%s at %s)",
+				i, e.Events[i].EventType, e.Events[i].Timestamp, i-1, e.Events[i-1].EventType, e.Events[i-1].Timestamp))
+		}
+	}
+
+	var coldChainEvents []CustodyEvent
+	for _, event := range e.Events {
+		if event.EventType == EventArchive || event.EventType == EventReactivate {
+			coldChainEvents = append(coldChainEvents, event)
+		}
+	}
+
+	expect := EventArchive
+	for i, event := range coldChainEvents {
+		if event.EventType == EventArchive {
+			result.ArchiveCount++
+		} else {
+			result.ReactivateCount++
+		}
+		if event.EventType != expect {
+			result.Anomalies = append(result.Anomalies, fmt.Sprintf("expected %s at position %d in the archive/reactivate sequence, found %s at %s", expect, i, event.EventType, event.Timestamp))
+			expect = event.EventType
+		}
+		if expect == EventArchive {
+			expect = EventReactivate
+		} else {
+			expect = EventArchive
+		}
+	}
+
+	result.Valid = len(result.Anomalies) == 0
+	return result, nil
+}
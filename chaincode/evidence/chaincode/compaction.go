@@ -0,0 +1,118 @@
+package chaincode
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// defaultCompactionKeepLast is the number of most recent custody events left
+// on the hot Evidence record by CompactEventHistory when the caller doesn't
+// override it.
+const defaultCompactionKeepLast = 10
+
+// CompactEventHistory moves all but the keepLast most recent custody events
+// of an evidence item into a new EventArchiveRecord segment, leaving a hash
+// pointer and running counts on the hot Evidence record. This bounds the
+// size of frequently-read Evidence documents for items with very long
+// custody trails; the full history remains available via GetFullCustodyChain.
+// Segments are append-only: calling this again archives the events that have
+// accumulated since the last call into a new, separately-keyed segment
+// rather than overwriting the previous one, so no archived event is ever
+// lost regardless of how many times compaction runs. keepLast <= 0 uses
+// defaultCompactionKeepLast.
+func (s *SmartContract) CompactEventHistory(ctx contractapi.TransactionContextInterface, caseID, evidenceID string, keepLast int) error {
+	if keepLast <= 0 {
+		keepLast = defaultCompactionKeepLast
+	}
+
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if len(e.Events) <= keepLast {
+		return nil
+	}
+
+	toArchive := e.Events[:len(e.Events)-keepLast]
+	remaining := e.Events[len(e.Events)-keepLast:]
+
+	segment := e.ArchivedSegmentCount + 1
+	archive := &EventArchiveRecord{
+		DocType:    "eventArchive",
+		CaseID:     caseID,
+		EvidenceID: evidenceID,
+		Segment:    segment,
+		Events:     toArchive,
+		PriorHash:  e.EventArchiveHash,
+	}
+	archive.Hash, err = hashEventArchive(archive)
+	if err != nil {
+		return err
+	}
+
+	archiveBytes, err := json.Marshal(archive)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event archive: %v", err)
+	}
+	if err := ctx.GetStub().PutState(eventArchiveKey(caseID, evidenceID, segment), archiveBytes); err != nil {
+		return fmt.Errorf("failed to write event archive: %v", err)
+	}
+
+	e.Events = remaining
+	e.ArchivedEventCount += len(toArchive)
+	e.ArchivedSegmentCount = segment
+	e.EventArchiveHash = archive.Hash
+
+	return putEvidence(ctx, e)
+}
+
+// GetFullCustodyChain returns the complete, ordered custody event history of
+// an evidence item, transparently stitching every compacted-out
+// EventArchiveRecord segment, oldest first, back in front of the live
+// events.
+func (s *SmartContract) GetFullCustodyChain(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) ([]CustodyEvent, error) {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return nil, err
+	}
+	if e.ArchivedSegmentCount == 0 {
+		return e.Events, nil
+	}
+
+	var archived []CustodyEvent
+	for segment := 1; segment <= e.ArchivedSegmentCount; segment++ {
+		bytes, err := ctx.GetStub().GetState(eventArchiveKey(caseID, evidenceID, segment))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read event archive %s/%s segment %d: %v", caseID, evidenceID, segment, err)
+		}
+		if bytes == nil {
+			return nil, fmt.Errorf("evidence %s reports %d archived segments but segment %d is missing", evidenceID, e.ArchivedSegmentCount, segment)
+		}
+		var archive EventArchiveRecord
+		if err := json.Unmarshal(bytes, &archive); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event archive %s/%s segment %d: %v", caseID, evidenceID, segment, err)
+		}
+		archived = append(archived, archive.Events...)
+	}
+
+	full := make([]CustodyEvent, 0, len(archived)+len(e.Events))
+	full = append(full, archived...)
+	full = append(full, e.Events...)
+	return full, nil
+}
+
+// hashEventArchive computes the hash-chain link for an EventArchiveRecord: a
+// SHA-256 digest over its prior hash and its own events, so tampering with
+// an older archive segment is detectable from the latest pointer alone.
+func hashEventArchive(archive *EventArchiveRecord) (string, error) {
+	eventsJSON, err := json.Marshal(archive.Events)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal archived events for hashing: %v", err)
+	}
+	sum := sha256.Sum256([]byte(archive.PriorHash + string(eventsJSON)))
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,88 @@
+package chaincode
+
+import "testing"
+
+func addEvents(t *testing.T, ctx *mockCtx, sc *SmartContract, caseID, evidenceID string, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if _, err := sc.UpdateMetadata(ctx, caseID, evidenceID, `{"note":"update"}`); err != nil {
+			t.Fatalf("UpdateMetadata failed: %v", err)
+		}
+	}
+}
+
+func TestCompactEventHistory_HappyPath(t *testing.T) {
+	ctx := newMockCtx()
+	sc := &SmartContract{}
+	mustCreate(t, ctx, sc, "case-1", "ev-1")
+	addEvents(t, ctx, sc, "case-1", "ev-1", 12)
+
+	full, err := sc.GetFullCustodyChain(ctx, "case-1", "ev-1")
+	if err != nil {
+		t.Fatalf("GetFullCustodyChain failed: %v", err)
+	}
+	totalBefore := len(full)
+
+	if err := sc.CompactEventHistory(ctx, "case-1", "ev-1", 5); err != nil {
+		t.Fatalf("CompactEventHistory failed: %v", err)
+	}
+
+	e, err := getEvidence(ctx, "case-1", "ev-1")
+	if err != nil {
+		t.Fatalf("getEvidence failed: %v", err)
+	}
+	if len(e.Events) != 5 {
+		t.Fatalf("expected 5 live events after compaction, got %d", len(e.Events))
+	}
+	if e.ArchivedSegmentCount != 1 {
+		t.Fatalf("expected 1 archive segment, got %d", e.ArchivedSegmentCount)
+	}
+
+	full, err = sc.GetFullCustodyChain(ctx, "case-1", "ev-1")
+	if err != nil {
+		t.Fatalf("GetFullCustodyChain failed: %v", err)
+	}
+	if len(full) != totalBefore {
+		t.Fatalf("expected GetFullCustodyChain to still return all %d events, got %d", totalBefore, len(full))
+	}
+}
+
+func TestCompactEventHistory_SecondCallDoesNotLoseFirstSegment(t *testing.T) {
+	ctx := newMockCtx()
+	sc := &SmartContract{}
+	mustCreate(t, ctx, sc, "case-1", "ev-1")
+	addEvents(t, ctx, sc, "case-1", "ev-1", 12)
+
+	full, err := sc.GetFullCustodyChain(ctx, "case-1", "ev-1")
+	if err != nil {
+		t.Fatalf("GetFullCustodyChain failed: %v", err)
+	}
+	totalBefore := len(full)
+
+	if err := sc.CompactEventHistory(ctx, "case-1", "ev-1", 5); err != nil {
+		t.Fatalf("first CompactEventHistory failed: %v", err)
+	}
+
+	addEvents(t, ctx, sc, "case-1", "ev-1", 8)
+
+	if err := sc.CompactEventHistory(ctx, "case-1", "ev-1", 5); err != nil {
+		t.Fatalf("second CompactEventHistory failed: %v", err)
+	}
+
+	e, err := getEvidence(ctx, "case-1", "ev-1")
+	if err != nil {
+		t.Fatalf("getEvidence failed: %v", err)
+	}
+	if e.ArchivedSegmentCount != 2 {
+		t.Fatalf("expected 2 archive segments after a second compaction, got %d", e.ArchivedSegmentCount)
+	}
+
+	full, err = sc.GetFullCustodyChain(ctx, "case-1", "ev-1")
+	if err != nil {
+		t.Fatalf("GetFullCustodyChain failed: %v", err)
+	}
+	want := totalBefore + 8
+	if len(full) != want {
+		t.Fatalf("expected GetFullCustodyChain to return all %d events across both archive segments, got %d", want, len(full))
+	}
+}
@@ -0,0 +1,95 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// contractConfigKeys lists every state key a policy setter in this
+// chaincode writes to. GetContractConfig/ImportContractConfig are
+// deliberately generic over this list, rather than a fixed struct field per
+// policy, so a new SetXxx added later only needs its key added here.
+var contractConfigKeys = []string{
+	adminMSPKey,
+	globalFreezeKey,
+	globalFreezeReasonKey,
+	custodianPolicyKey,
+	oracleMSPKey,
+	allowedContentTypesKey,
+	maxEventsPolicyKey,
+	minReasonLengthPolicyKey,
+	invalidationWindowPolicyKey,
+	backdatePolicyKey,
+	witnessPolicyKey,
+	reactivationApprovalPolicyKey,
+	transferRevokeWindowKey,
+	quarantineOnFailureKey,
+	stateDBTypeKey,
+	scoringWeightsKey,
+	reasonCodesKey(EventInvalidate),
+	reasonCodesKey(EventArchive),
+	enforceCertValidityKey,
+	processingLockTTLKey,
+	requiredMetadataKeysKey,
+	requireAcquisitionToolKey,
+}
+
+// ContractConfig is a snapshot of every stored policy value, for auditing
+// and backing up the chaincode's governance configuration in one call.
+// Values holds the raw state bytes for each configured key, as a string;
+// unset keys are omitted.
+type ContractConfig struct {
+	Values map[string]string `json:"values"`
+}
+
+// GetContractConfig returns every currently stored policy value, keyed by
+// its state key, so ops can audit or back up the full governance
+// configuration in one call instead of tracking down each SetXxx's key.
+func (s *SmartContract) GetContractConfig(ctx contractapi.TransactionContextInterface) (*ContractConfig, error) {
+	config := &ContractConfig{Values: map[string]string{}}
+	for _, key := range contractConfigKeys {
+		bytes, err := ctx.GetStub().GetState(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config key %s: %v", key, err)
+		}
+		if bytes == nil {
+			continue
+		}
+		config.Values[key] = string(bytes)
+	}
+	return config, nil
+}
+
+// ImportContractConfig restores every value in config.Values, admin-only.
+// Keys not in contractConfigKeys are rejected rather than silently written,
+// since an unrecognized key is most likely a typo or a stale export from a
+// newer chaincode version with policies this build doesn't know about.
+func (s *SmartContract) ImportContractConfig(ctx contractapi.TransactionContextInterface, configJSON string) error {
+	if err := requireAdminMSP(ctx); err != nil {
+		return err
+	}
+
+	var config ContractConfig
+	if err := json.Unmarshal([]byte(configJSON), &config); err != nil {
+		return fmt.Errorf("invalid config JSON: %v", err)
+	}
+
+	known := make(map[string]bool, len(contractConfigKeys))
+	for _, key := range contractConfigKeys {
+		known[key] = true
+	}
+
+	for key := range config.Values {
+		if !known[key] {
+			return fmt.Errorf("unrecognized config key %q", key)
+		}
+	}
+	for key, value := range config.Values {
+		if err := ctx.GetStub().PutState(key, []byte(value)); err != nil {
+			return fmt.Errorf("failed to write config key %s: %v", key, err)
+		}
+	}
+	return nil
+}
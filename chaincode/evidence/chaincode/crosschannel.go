@@ -0,0 +1,146 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// CrossChannelTransferPayload is the provenance-carrying record a client
+// captures from CrossChannelTransfer and submits to the destination
+// channel's CrossChannelReceive, since a chaincode transaction can't write
+// to another channel directly.
+type CrossChannelTransferPayload struct {
+	SourceChannel  string            `json:"sourceChannel"`
+	TransferToken  string            `json:"transferToken"`
+	CaseID         string            `json:"caseID"`
+	EvidenceID     string            `json:"evidenceID"`
+	Hash           string            `json:"hash"`
+	CID            string            `json:"cid"`
+	Metadata       map[string]string `json:"metadata"`
+	ContentType    string            `json:"contentType"`
+	HashAlgorithm  string            `json:"hashAlgorithm"`
+	Classification string            `json:"classification"`
+}
+
+// CrossChannelTransfer hands evidence custody off to another channel (a
+// different jurisdiction's ledger), recording an OUTBOUND_TRANSFER event
+// and moving the evidence to StatusTransferredOut, which operations gated on
+// status (transfers, archival, invalidation, checkout) reject on this
+// channel; operations that don't check status still apply. It returns the
+// payload a client must submit to
+// destinationChannelID's CrossChannelReceive to complete the handoff there.
+func (s *SmartContract) CrossChannelTransfer(ctx contractapi.TransactionContextInterface, caseID, evidenceID, destinationChannelID, reason string) (*CrossChannelTransferPayload, error) {
+	if err := validateID("destinationChannelID", destinationChannelID); err != nil {
+		return nil, err
+	}
+	sourceChannel := ctx.GetStub().GetChannelID()
+	if destinationChannelID == sourceChannel {
+		return nil, fmt.Errorf("destinationChannelID must differ from the current channel (%s)", sourceChannel)
+	}
+
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTransition(e, "cross-channel transfer", []string{StatusActive}, "only active evidence can be handed off to another channel"); err != nil {
+		return nil, err
+	}
+	if e.Disputed {
+		return nil, fmt.Errorf("cannot cross-channel transfer evidence %s: it is frozen pending dispute resolution (%s)", evidenceID, e.DisputeRef)
+	}
+
+	token := ctx.GetStub().GetTxID()
+	event, err := newCustodyEvent(ctx, EventOutboundTransfer, e.CurrentOwner, e.CurrentOwner, "", reason)
+	if err != nil {
+		return nil, err
+	}
+	event.Channel = destinationChannelID
+
+	e.Status = StatusTransferredOut
+	e.OutboundChannel = destinationChannelID
+	e.OutboundTransferToken = token
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+	if err := putEvidence(ctx, e); err != nil {
+		return nil, err
+	}
+
+	return &CrossChannelTransferPayload{
+		SourceChannel:  sourceChannel,
+		TransferToken:  token,
+		CaseID:         caseID,
+		EvidenceID:     evidenceID,
+		Hash:           e.Hash,
+		CID:            e.CID,
+		Metadata:       e.Metadata,
+		ContentType:    e.ContentType,
+		HashAlgorithm:  e.HashAlgorithm,
+		Classification: e.Classification,
+	}, nil
+}
+
+// CrossChannelReceive consumes a CrossChannelTransferPayload produced by
+// another channel's CrossChannelTransfer, creating the evidence record here
+// under newCustodian with a linked provenance pointer (SourceChannel,
+// SourceTransferToken) back to where it came from.
+func (s *SmartContract) CrossChannelReceive(ctx contractapi.TransactionContextInterface, payloadJSON, newCustodian string) error {
+	var payload CrossChannelTransferPayload
+	if err := json.Unmarshal([]byte(payloadJSON), &payload); err != nil {
+		return fmt.Errorf("invalid payload JSON: %v", err)
+	}
+	if payload.SourceChannel == "" || payload.TransferToken == "" {
+		return fmt.Errorf("payload is missing sourceChannel or transferToken")
+	}
+	if err := validateID("newCustodian", newCustodian); err != nil {
+		return err
+	}
+
+	exists, err := s.EvidenceExists(ctx, payload.CaseID, payload.EvidenceID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("evidence %s already exists in case %s on this channel", payload.EvidenceID, payload.CaseID)
+	}
+
+	orgMSP, err := getClientOrgMSP(ctx)
+	if err != nil {
+		return err
+	}
+	ts, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	event, err := newCustodyEvent(ctx, EventInboundTransfer, newCustodian, "", newCustodian, fmt.Sprintf("received via cross-channel transfer from %s", payload.SourceChannel))
+	if err != nil {
+		return err
+	}
+	event.Channel = payload.SourceChannel
+
+	evidence := &Evidence{
+		DocType:             "evidence",
+		CaseID:              payload.CaseID,
+		EvidenceID:          payload.EvidenceID,
+		Hash:                payload.Hash,
+		CID:                 payload.CID,
+		CIDs:                []string{payload.CID},
+		Metadata:            payload.Metadata,
+		Status:              StatusActive,
+		CurrentOwner:        newCustodian,
+		OrgMSP:              orgMSP,
+		CreatedBy:           newCustodian,
+		CreatedAt:           ts,
+		UpdatedAt:           ts,
+		SchemaVersion:       CurrentSchemaVersion,
+		ContentType:         payload.ContentType,
+		HashAlgorithm:       payload.HashAlgorithm,
+		Classification:      payload.Classification,
+		SourceChannel:       payload.SourceChannel,
+		SourceTransferToken: payload.TransferToken,
+		Events:              []CustodyEvent{event},
+	}
+	return putEvidence(ctx, evidence)
+}
@@ -0,0 +1,149 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// custodianPolicyKey stores whether TransferCustody requires the recipient
+// to be a registered, active custodian.
+const custodianPolicyKey = "POLICY_REQUIRE_REGISTERED_CUSTODIAN"
+
+// RegisterCustodian adds (or reactivates) a custodian in the registry so
+// transfers can be validated against real, known people instead of a raw
+// identifier string.
+func (s *SmartContract) RegisterCustodian(ctx contractapi.TransactionContextInterface, id, name, orgMSP string) error {
+	if err := validateID("id", id); err != nil {
+		return err
+	}
+	if name == "" || orgMSP == "" {
+		return fmt.Errorf("name and orgMSP are required")
+	}
+
+	custodian := &Custodian{
+		DocType: "custodian",
+		ID:      id,
+		Name:    name,
+		OrgMSP:  orgMSP,
+		Active:  true,
+	}
+	bytes, err := json.Marshal(custodian)
+	if err != nil {
+		return fmt.Errorf("failed to marshal custodian: %v", err)
+	}
+	return ctx.GetStub().PutState(custodianKey(id), bytes)
+}
+
+// DeregisterCustodian marks a custodian inactive. The record is kept (not
+// deleted) so historical custody chains that reference it still resolve.
+func (s *SmartContract) DeregisterCustodian(ctx contractapi.TransactionContextInterface, id string) error {
+	custodian, err := s.GetCustodian(ctx, id)
+	if err != nil {
+		return err
+	}
+	custodian.Active = false
+	bytes, err := json.Marshal(custodian)
+	if err != nil {
+		return fmt.Errorf("failed to marshal custodian: %v", err)
+	}
+	return ctx.GetStub().PutState(custodianKey(id), bytes)
+}
+
+// GetCustodian returns a single registered custodian by ID.
+func (s *SmartContract) GetCustodian(ctx contractapi.TransactionContextInterface, id string) (*Custodian, error) {
+	if err := validateID("id", id); err != nil {
+		return nil, err
+	}
+	bytes, err := ctx.GetStub().GetState(custodianKey(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custodian %s: %v", id, err)
+	}
+	if bytes == nil {
+		return nil, fmt.Errorf("custodian %s is not registered", id)
+	}
+	var custodian Custodian
+	if err := json.Unmarshal(bytes, &custodian); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal custodian %s: %v", id, err)
+	}
+	return &custodian, nil
+}
+
+// ListCustodians returns every registered custodian, active or not.
+func (s *SmartContract) ListCustodians(ctx contractapi.TransactionContextInterface) ([]*Custodian, error) {
+	iterator, err := ctx.GetStub().GetStateByRange(custodianKeyPrefix, custodianKeyPrefix+"￿")
+	if err != nil {
+		return nil, fmt.Errorf("failed to range over custodians: %v", err)
+	}
+	defer iterator.Close()
+
+	var custodians []*Custodian
+	for iterator.HasNext() {
+		result, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		var custodian Custodian
+		if err := json.Unmarshal(result.Value, &custodian); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal custodian: %v", err)
+		}
+		custodians = append(custodians, &custodian)
+	}
+	return custodians, nil
+}
+
+// SetRequireRegisteredCustodian toggles whether TransferCustody rejects
+// recipients that aren't registered, active custodians.
+func (s *SmartContract) SetRequireRegisteredCustodian(ctx contractapi.TransactionContextInterface, required bool) error {
+	value := "false"
+	if required {
+		value = "true"
+	}
+	return ctx.GetStub().PutState(custodianPolicyKey, []byte(value))
+}
+
+func (s *SmartContract) requiresRegisteredCustodian(ctx contractapi.TransactionContextInterface) (bool, error) {
+	bytes, err := ctx.GetStub().GetState(custodianPolicyKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to read custodian validation policy: %v", err)
+	}
+	return string(bytes) == "true", nil
+}
+
+// ReassignCustodianEvidence transfers every active item currently held by
+// oldCustodian to newCustodian, for use when a custodian leaves the
+// organization. Each transfer records a REASSIGN-tagged reason so the
+// departure is visible in the custody trail.
+func (s *SmartContract) ReassignCustodianEvidence(ctx contractapi.TransactionContextInterface, oldCustodian, newCustodian, reason string) (*BatchResult, error) {
+	if err := requireSupervisor(ctx); err != nil {
+		return nil, err
+	}
+	if err := validateID("oldCustodian", oldCustodian); err != nil {
+		return nil, err
+	}
+	if err := validateID("newCustodian", newCustodian); err != nil {
+		return nil, err
+	}
+
+	owned, err := s.QueryEvidencesByOwner(ctx, oldCustodian)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newBatchResult()
+	transferReason := fmt.Sprintf("REASSIGN (custodian departure): %s", reason)
+	for _, e := range owned {
+		if e.Status != StatusActive {
+			continue
+		}
+		key := e.CaseID + "_" + e.EvidenceID
+		if err := s.TransferCustody(ctx, e.CaseID, e.EvidenceID, newCustodian, transferReason, "", "", ""); err != nil {
+			result.Failed[key] = err.Error()
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, key)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,38 @@
+package chaincode
+
+import (
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// GetCustodianHistory returns every custodian who ever held evidenceID, in
+// the order they first took custody, deduplicated. It's built from CREATE
+// (the originating creator) and TRANSFER (ToOwner) events, which is exactly
+// who CurrentOwner transitioned through over the item's life. This produces
+// the "who touched it" witness list prosecutors request for every exhibit.
+func (s *SmartContract) GetCustodianHistory(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) ([]string, error) {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var custodians []string
+	for _, event := range e.Events {
+		var custodian string
+		switch event.EventType {
+		case EventCreate:
+			custodian = event.Actor
+		case EventTransfer:
+			custodian = event.ToOwner
+		default:
+			continue
+		}
+		if custodian == "" || seen[custodian] {
+			continue
+		}
+		seen[custodian] = true
+		custodians = append(custodians, custodian)
+	}
+
+	return custodians, nil
+}
@@ -0,0 +1,67 @@
+package chaincode
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// GetCustodyDurations returns, for each custodian who has ever held
+// evidenceID, the total number of seconds they held it: the time between
+// each TRANSFER (or the CREATE event, for the first holder) and the next
+// ownership change, with the current holder's period ending at the current
+// transaction's timestamp. Durations are summed across non-contiguous
+// periods, in case a custodian held the item more than once.
+func (s *SmartContract) GetCustodyDurations(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) (map[string]int64, error) {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	durations := map[string]int64{}
+	var holder string
+	var since string
+	for _, event := range e.Events {
+		switch event.EventType {
+		case EventCreate:
+			holder = event.Actor
+			since = event.Timestamp
+		case EventTransfer:
+			if holder != "" {
+				seconds, err := secondsBetween(since, event.Timestamp)
+				if err != nil {
+					return nil, err
+				}
+				durations[holder] += seconds
+			}
+			holder = event.ToOwner
+			since = event.Timestamp
+		}
+	}
+	if holder != "" {
+		seconds, err := secondsBetween(since, now)
+		if err != nil {
+			return nil, err
+		}
+		durations[holder] += seconds
+	}
+	return durations, nil
+}
+
+func secondsBetween(start, end string) (int64, error) {
+	startTime, err := time.Parse(time.RFC3339Nano, start)
+	if err != nil {
+		return 0, fmt.Errorf("corrupt timestamp %q: %v", start, err)
+	}
+	endTime, err := time.Parse(time.RFC3339Nano, end)
+	if err != nil {
+		return 0, fmt.Errorf("corrupt timestamp %q: %v", end, err)
+	}
+	return int64(endTime.Sub(startTime).Seconds()), nil
+}
@@ -0,0 +1,75 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// MarkDisputed freezes an evidence item pending a ruling on a defense
+// motion, distinct from LegalHold. While disputed, transfers and archival
+// are blocked, but verification and annotation remain allowed.
+func (s *SmartContract) MarkDisputed(ctx contractapi.TransactionContextInterface, caseID, evidenceID, motionRef string) error {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if e.Disputed {
+		return fmt.Errorf("evidence %s is already disputed", evidenceID)
+	}
+	if motionRef == "" {
+		return fmt.Errorf("motionRef is required")
+	}
+
+	event, err := newCustodyEvent(ctx, EventDispute, e.CurrentOwner, "", "", motionRef)
+	if err != nil {
+		return err
+	}
+
+	e.Disputed = true
+	e.DisputeRef = motionRef
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return putEvidence(ctx, e)
+}
+
+// ResolveDispute lifts a dispute freeze, recording the ruling on a
+// RESOLVE_DISPUTE event.
+func (s *SmartContract) ResolveDispute(ctx contractapi.TransactionContextInterface, caseID, evidenceID, ruling string) error {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if !e.Disputed {
+		return fmt.Errorf("evidence %s is not disputed", evidenceID)
+	}
+	if ruling == "" {
+		return fmt.Errorf("ruling is required")
+	}
+
+	event, err := newCustodyEvent(ctx, EventResolve, e.CurrentOwner, "", "", ruling)
+	if err != nil {
+		return err
+	}
+
+	e.Disputed = false
+	e.DisputeRef = ""
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return putEvidence(ctx, e)
+}
+
+// QueryDisputedEvidence returns every evidence record in a case that is
+// currently under dispute.
+func (s *SmartContract) QueryDisputedEvidence(ctx contractapi.TransactionContextInterface, caseID string) ([]*Evidence, error) {
+	if err := validateID("caseID", caseID); err != nil {
+		return nil, err
+	}
+	queryString, err := buildSelectorQuery(map[string]interface{}{"docType": "evidence", "caseID": caseID, "disputed": true})
+	if err != nil {
+		return nil, err
+	}
+	return getQueryResultForQueryString(ctx, queryString)
+}
@@ -0,0 +1,38 @@
+package chaincode
+
+import "github.com/hyperledger/fabric-contract-api-go/contractapi"
+
+// GetCustodyDistribution tallies a case's evidence by current owner, for the
+// case dashboard's custody pie chart. Doing the aggregation here avoids the
+// dashboard making one call per custodian.
+func (s *SmartContract) GetCustodyDistribution(ctx contractapi.TransactionContextInterface, caseID string) (map[string]int, error) {
+	evidences, err := s.QueryEvidencesByCase(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+	distribution := map[string]int{}
+	for _, e := range evidences {
+		distribution[e.CurrentOwner]++
+	}
+	return distribution, nil
+}
+
+// GetCustodyDistributionByStatus is GetCustodyDistribution broken out by
+// status, keyed owner then status, for dashboards that also want to show
+// how much of each custodian's holdings are archived/invalidated/etc.
+func (s *SmartContract) GetCustodyDistributionByStatus(ctx contractapi.TransactionContextInterface, caseID string) (map[string]map[string]int, error) {
+	evidences, err := s.QueryEvidencesByCase(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+	distribution := map[string]map[string]int{}
+	for _, e := range evidences {
+		byStatus, ok := distribution[e.CurrentOwner]
+		if !ok {
+			byStatus = map[string]int{}
+			distribution[e.CurrentOwner] = byStatus
+		}
+		byStatus[e.Status]++
+	}
+	return distribution, nil
+}
@@ -0,0 +1,60 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// allowedDocumentTypes are the physical/paper record types AttachDocument
+// accepts, bridging our digital evidence with jurisdictions that still
+// require a signed paper trail.
+var allowedDocumentTypes = map[string]bool{
+	"CUSTODY_FORM": true,
+	"CHAIN_FORM":   true,
+	"WARRANT":      true,
+}
+
+// AttachDocument anchors the hash of a physical/paper record alongside an
+// evidence item's digital custody trail, appending an ANNOTATE event.
+func (s *SmartContract) AttachDocument(ctx contractapi.TransactionContextInterface, caseID, evidenceID, docType, docHash, docCID string) error {
+	if !allowedDocumentTypes[docType] {
+		return fmt.Errorf("unsupported document type %q", docType)
+	}
+	if docHash == "" {
+		return fmt.Errorf("docHash is required")
+	}
+
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+
+	event, err := newCustodyEvent(ctx, EventAnnotate, e.CurrentOwner, "", "", fmt.Sprintf("attached %s document", docType))
+	if err != nil {
+		return err
+	}
+
+	e.AttachedDocuments = append(e.AttachedDocuments, AttachedDocument{
+		DocumentType: docType,
+		DocHash:      docHash,
+		DocCID:       docCID,
+	})
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return putEvidence(ctx, e)
+}
+
+// VerifyDocument checks providedHash against the hash recorded for the
+// attached document at docIndex.
+func (s *SmartContract) VerifyDocument(ctx contractapi.TransactionContextInterface, caseID, evidenceID string, docIndex int, providedHash string) (bool, error) {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return false, err
+	}
+	if docIndex < 0 || docIndex >= len(e.AttachedDocuments) {
+		return false, fmt.Errorf("document index %d out of range for evidence %s (%d attached)", docIndex, evidenceID, len(e.AttachedDocuments))
+	}
+	return providedHash == e.AttachedDocuments[docIndex].DocHash, nil
+}
@@ -0,0 +1,143 @@
+package chaincode
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// embargoOverrideRoleValue is the expected value of the "role" client
+// certificate attribute that lets a caller read an embargoed evidence
+// record in full, or mutate it, before EmbargoUntil passes.
+const embargoOverrideRoleValue = "embargo-override"
+
+// requireEmbargoOverride rejects the call unless the submitting identity
+// carries the embargo-override role attribute on its enrollment certificate.
+func requireEmbargoOverride(ctx contractapi.TransactionContextInterface) error {
+	role, ok, err := ctx.GetClientIdentity().GetAttributeValue("role")
+	if err != nil {
+		return fmt.Errorf("failed to read caller role attribute: %v", err)
+	}
+	if !ok || role != embargoOverrideRoleValue {
+		return fmt.Errorf("this operation is restricted to the embargo-override role")
+	}
+	return nil
+}
+
+// isEmbargoed reports whether e is currently under an embargo that has not
+// yet lifted, i.e. EmbargoUntil is set and the transaction timestamp is
+// still before it.
+func isEmbargoed(ctx contractapi.TransactionContextInterface, e *Evidence) (bool, error) {
+	if e.EmbargoUntil == "" {
+		return false, nil
+	}
+	until, err := time.Parse(time.RFC3339Nano, e.EmbargoUntil)
+	if err != nil {
+		return false, fmt.Errorf("corrupt EmbargoUntil on evidence %s: %v", e.EvidenceID, err)
+	}
+	ts, err := getTxTimestamp(ctx)
+	if err != nil {
+		return false, err
+	}
+	now, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return false, err
+	}
+	return now.Before(until), nil
+}
+
+// requireNotEmbargoed rejects a mutation while e is embargoed, unless the
+// caller holds the embargo-override role. Checked by TransferCustody (and the
+// other transfers built on buildTransferCustody), TransferAndArchive,
+// ArchiveToCold, CheckOutEvidence and UpdateMetadata; other mutators (e.g.
+// InvalidateEvidence, AddAnnotation, AddTag) do not check it.
+func requireNotEmbargoed(ctx contractapi.TransactionContextInterface, e *Evidence) error {
+	embargoed, err := isEmbargoed(ctx, e)
+	if err != nil {
+		return err
+	}
+	if !embargoed {
+		return nil
+	}
+	if err := requireEmbargoOverride(ctx); err != nil {
+		return fmt.Errorf("evidence %s is embargoed until %s: %v", e.EvidenceID, e.EmbargoUntil, err)
+	}
+	return nil
+}
+
+// SetEmbargo seals evidenceID until untilTimestamp: GetEvidence returns only
+// a minimal stub and the mutators listed on requireNotEmbargoed are rejected
+// until LiftEmbargo is called or untilTimestamp passes, unless the caller
+// holds the embargo-override role; other mutators are not blocked.
+// Supervisor-only, since it restricts access for
+// everyone else.
+func (s *SmartContract) SetEmbargo(ctx contractapi.TransactionContextInterface, caseID, evidenceID, untilTimestamp, reason string) error {
+	if err := requireSupervisor(ctx); err != nil {
+		return err
+	}
+	until, err := normalizeTimestamp(untilTimestamp)
+	if err != nil {
+		return err
+	}
+	if reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+
+	event, err := newCustodyEvent(ctx, EventEmbargo, e.CurrentOwner, "", "", reason)
+	if err != nil {
+		return err
+	}
+
+	e.EmbargoUntil = until
+	e.EmbargoReason = reason
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return putEvidence(ctx, e)
+}
+
+// LiftEmbargo clears an embargo set by SetEmbargo before its EmbargoUntil
+// has passed. Supervisor-only.
+func (s *SmartContract) LiftEmbargo(ctx contractapi.TransactionContextInterface, caseID, evidenceID, reason string) error {
+	if err := requireSupervisor(ctx); err != nil {
+		return err
+	}
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if e.EmbargoUntil == "" {
+		return fmt.Errorf("evidence %s is not embargoed", evidenceID)
+	}
+
+	event, err := newCustodyEvent(ctx, EventLiftEmbargo, e.CurrentOwner, "", "", reason)
+	if err != nil {
+		return err
+	}
+
+	e.EmbargoUntil = ""
+	e.EmbargoReason = ""
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return putEvidence(ctx, e)
+}
+
+// embargoedStub is what GetEvidence returns in place of the full record
+// while evidenceID is embargoed and the caller lacks the embargo-override
+// role: enough to confirm the record exists and is sealed, nothing else.
+func embargoedStub(e *Evidence) *Evidence {
+	return &Evidence{
+		DocType:       e.DocType,
+		CaseID:        e.CaseID,
+		EvidenceID:    e.EvidenceID,
+		Status:        e.Status,
+		EmbargoUntil:  e.EmbargoUntil,
+		EmbargoReason: e.EmbargoReason,
+	}
+}
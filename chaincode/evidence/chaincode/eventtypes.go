@@ -0,0 +1,24 @@
+package chaincode
+
+import "github.com/hyperledger/fabric-contract-api-go/contractapi"
+
+// GetEventTypes returns the distinct event types present in evidenceID's
+// custody trail, in first-occurrence order, so a UI filter dropdown can be
+// built without fetching the full chain.
+func (s *SmartContract) GetEventTypes(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) ([]string, error) {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	types := make([]string, 0, len(e.Events))
+	for _, event := range e.Events {
+		if seen[event.EventType] {
+			continue
+		}
+		seen[event.EventType] = true
+		types = append(types, event.EventType)
+	}
+	return types, nil
+}
@@ -0,0 +1,59 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// validateEvidenceKind defaults an empty evidenceKind to ORIGINAL and checks
+// that, for a copy, originalEvidenceID is set (and vice versa).
+func validateEvidenceKind(evidenceKind, originalEvidenceID string) (string, error) {
+	if evidenceKind == "" {
+		evidenceKind = EvidenceKindOriginal
+	}
+	switch evidenceKind {
+	case EvidenceKindOriginal:
+		if originalEvidenceID != "" {
+			return "", fmt.Errorf("originalEvidenceID must be empty for an ORIGINAL")
+		}
+	case EvidenceKindForensicCopy, EvidenceKindWorkingCopy:
+		if originalEvidenceID == "" {
+			return "", fmt.Errorf("originalEvidenceID is required for a %s", evidenceKind)
+		}
+	default:
+		return "", fmt.Errorf("evidenceKind must be one of ORIGINAL, FORENSIC_COPY, WORKING_COPY, got %q", evidenceKind)
+	}
+	return evidenceKind, nil
+}
+
+// checkOriginalForCopy looks up originalEvidenceID and rejects making a
+// WORKING_COPY of an original that's already been invalidated: an examiner
+// shouldn't keep producing working material from evidence known to be
+// compromised.
+func (s *SmartContract) checkOriginalForCopy(ctx contractapi.TransactionContextInterface, caseID, evidenceKind, originalEvidenceID string) error {
+	if originalEvidenceID == "" {
+		return nil
+	}
+	original, err := getEvidence(ctx, caseID, originalEvidenceID)
+	if err != nil {
+		return fmt.Errorf("originalEvidenceID %s must already be registered: %v", originalEvidenceID, err)
+	}
+	if evidenceKind == EvidenceKindWorkingCopy && original.Status == StatusInvalidated {
+		return fmt.Errorf("cannot make a working copy of invalidated evidence %s", originalEvidenceID)
+	}
+	return nil
+}
+
+// QueryCopiesOfEvidence returns every forensic or working copy registered
+// against originalID within caseID.
+func (s *SmartContract) QueryCopiesOfEvidence(ctx contractapi.TransactionContextInterface, caseID, originalID string) ([]*Evidence, error) {
+	if err := validateID("originalID", originalID); err != nil {
+		return nil, err
+	}
+	queryString, err := buildSelectorQuery(map[string]interface{}{"docType": "evidence", "caseID": caseID, "originalEvidenceID": originalID})
+	if err != nil {
+		return nil, err
+	}
+	return getQueryResultForQueryString(ctx, queryString)
+}
@@ -0,0 +1,117 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+func examinerKey(id string) string {
+	return examinerKeyPrefix + id
+}
+
+// RegisterExaminer adds (or re-registers) a certified examiner so
+// examinerID/credentialRef arguments on custody events can be validated
+// against a real, known badge number instead of a raw string.
+func (s *SmartContract) RegisterExaminer(ctx contractapi.TransactionContextInterface, id, name, credentialRef string) error {
+	if err := validateID("id", id); err != nil {
+		return err
+	}
+	if name == "" || credentialRef == "" {
+		return fmt.Errorf("name and credentialRef are required")
+	}
+
+	examiner := &Examiner{
+		DocType:       "examiner",
+		ID:            id,
+		Name:          name,
+		CredentialRef: credentialRef,
+		Active:        true,
+	}
+	bytes, err := json.Marshal(examiner)
+	if err != nil {
+		return fmt.Errorf("failed to marshal examiner: %v", err)
+	}
+	return ctx.GetStub().PutState(examinerKey(id), bytes)
+}
+
+// GetExaminer returns a single registered examiner by ID.
+func (s *SmartContract) GetExaminer(ctx contractapi.TransactionContextInterface, id string) (*Examiner, error) {
+	if err := validateID("id", id); err != nil {
+		return nil, err
+	}
+	bytes, err := ctx.GetStub().GetState(examinerKey(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read examiner %s: %v", id, err)
+	}
+	if bytes == nil {
+		return nil, fmt.Errorf("examiner %s is not registered", id)
+	}
+	var examiner Examiner
+	if err := json.Unmarshal(bytes, &examiner); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal examiner %s: %v", id, err)
+	}
+	return &examiner, nil
+}
+
+// validateExaminer is a no-op when examinerID is empty (the field is
+// optional on every caller), and otherwise requires the examiner to be
+// registered, active, and presented with their registered credentialRef.
+func (s *SmartContract) validateExaminer(ctx contractapi.TransactionContextInterface, examinerID, credentialRef string) error {
+	if examinerID == "" {
+		return nil
+	}
+	examiner, err := s.GetExaminer(ctx, examinerID)
+	if err != nil {
+		return err
+	}
+	if !examiner.Active {
+		return fmt.Errorf("examiner %s is deregistered", examinerID)
+	}
+	if credentialRef != examiner.CredentialRef {
+		return fmt.Errorf("credentialRef does not match examiner %s's registered credential", examinerID)
+	}
+	return nil
+}
+
+// QueryEventsByExaminer lists every custody event a given examiner
+// performed, across every case, for expert-witness qualification.
+func (s *SmartContract) QueryEventsByExaminer(ctx contractapi.TransactionContextInterface, examinerID string) ([]AuditLogEntry, error) {
+	if err := validateID("examinerID", examinerID); err != nil {
+		return nil, err
+	}
+
+	iterator, err := ctx.GetStub().GetStateByRange(evidenceKeyPrefix, evidenceKeyPrefix+"￿")
+	if err != nil {
+		return nil, fmt.Errorf("failed to range over evidence: %v", err)
+	}
+	defer iterator.Close()
+
+	var entries []AuditLogEntry
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		var e Evidence
+		if err := json.Unmarshal(item.Value, &e); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal evidence: %v", err)
+		}
+		for _, event := range e.Events {
+			if event.ExaminerID != examinerID {
+				continue
+			}
+			entries = append(entries, AuditLogEntry{
+				CaseID:       e.CaseID,
+				EvidenceID:   e.EvidenceID,
+				CustodyEvent: event,
+			})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Timestamp < entries[j].Timestamp
+	})
+	return entries, nil
+}
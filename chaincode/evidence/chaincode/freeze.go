@@ -0,0 +1,126 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// adminMSPKey stores the single org MSP ID permitted to call SetGlobalFreeze,
+// our break-glass control for a suspected compromise.
+const adminMSPKey = "POLICY_ADMIN_MSP"
+
+// globalFreezeKey stores whether evidence mutations are currently halted,
+// and globalFreezeReasonKey why.
+const (
+	globalFreezeKey       = "POLICY_GLOBAL_FREEZE"
+	globalFreezeReasonKey = "POLICY_GLOBAL_FREEZE_REASON"
+)
+
+// GlobalFreezeStatus reports whether evidence mutations are currently
+// halted network-wide, and why.
+type GlobalFreezeStatus struct {
+	Frozen bool   `json:"frozen"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// SetAdminMSP designates the org MSP permitted to call SetGlobalFreeze. It
+// can only be set once by bootstrap, or rotated by the current admin org,
+// so no other org can silently grant itself break-glass authority.
+func (s *SmartContract) SetAdminMSP(ctx contractapi.TransactionContextInterface, mspID string) error {
+	if mspID == "" {
+		return fmt.Errorf("mspID is required")
+	}
+	current, err := ctx.GetStub().GetState(adminMSPKey)
+	if err != nil {
+		return fmt.Errorf("failed to read admin MSP: %v", err)
+	}
+	if current != nil {
+		callerMSP, err := getClientOrgMSP(ctx)
+		if err != nil {
+			return err
+		}
+		if callerMSP != string(current) {
+			return fmt.Errorf("only the current admin org (%s) may change the admin MSP", string(current))
+		}
+	}
+	return ctx.GetStub().PutState(adminMSPKey, []byte(mspID))
+}
+
+// requireAdminMSP rejects the call unless it comes from the configured
+// admin org.
+func requireAdminMSP(ctx contractapi.TransactionContextInterface) error {
+	adminMSP, err := ctx.GetStub().GetState(adminMSPKey)
+	if err != nil {
+		return fmt.Errorf("failed to read admin MSP: %v", err)
+	}
+	if adminMSP == nil {
+		return fmt.Errorf("no admin MSP configured; call SetAdminMSP first")
+	}
+	callerMSP, err := getClientOrgMSP(ctx)
+	if err != nil {
+		return err
+	}
+	if callerMSP != string(adminMSP) {
+		return fmt.Errorf("caller org %s is not the configured admin org", callerMSP)
+	}
+	return nil
+}
+
+// SetGlobalFreeze halts (or resumes) all evidence mutations network-wide,
+// our break-glass control for a suspected compromise. Read-only queries
+// remain available throughout. Restricted to the configured admin MSP.
+func (s *SmartContract) SetGlobalFreeze(ctx contractapi.TransactionContextInterface, frozen bool, reason string) error {
+	if err := requireAdminMSP(ctx); err != nil {
+		return err
+	}
+	if frozen && reason == "" {
+		return fmt.Errorf("reason is required when freezing")
+	}
+
+	value := "false"
+	if frozen {
+		value = "true"
+	}
+	if err := ctx.GetStub().PutState(globalFreezeKey, []byte(value)); err != nil {
+		return fmt.Errorf("failed to write global freeze flag: %v", err)
+	}
+	return ctx.GetStub().PutState(globalFreezeReasonKey, []byte(reason))
+}
+
+// GetFreezeStatus reports whether evidence mutations are currently frozen.
+func (s *SmartContract) GetFreezeStatus(ctx contractapi.TransactionContextInterface) (*GlobalFreezeStatus, error) {
+	frozen, reason, err := globalFreezeState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &GlobalFreezeStatus{Frozen: frozen, Reason: reason}, nil
+}
+
+func globalFreezeState(ctx contractapi.TransactionContextInterface) (bool, string, error) {
+	frozenBytes, err := ctx.GetStub().GetState(globalFreezeKey)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to read global freeze flag: %v", err)
+	}
+	if string(frozenBytes) != "true" {
+		return false, "", nil
+	}
+	reasonBytes, err := ctx.GetStub().GetState(globalFreezeReasonKey)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to read global freeze reason: %v", err)
+	}
+	return true, string(reasonBytes), nil
+}
+
+// checkGlobalFreeze rejects a mutation with the configured freeze reason if
+// evidence mutations are currently halted network-wide.
+func checkGlobalFreeze(ctx contractapi.TransactionContextInterface) error {
+	frozen, reason, err := globalFreezeState(ctx)
+	if err != nil {
+		return err
+	}
+	if frozen {
+		return fmt.Errorf("evidence mutations are frozen network-wide: %s", reason)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package chaincode
+
+import "fmt"
+
+// DefaultHashAlgorithm is used when CreateEvidence is called with an empty
+// hashAlgorithm, so existing callers don't have to change.
+const DefaultHashAlgorithm = "SHA-256"
+
+// hexDigestLength is the expected length, in hex characters, of a hash
+// produced by each supported algorithm.
+var hexDigestLength = map[string]int{
+	"SHA-256":  64,
+	"SHA-512":  128,
+	"SHA3-256": 64,
+	"SHA3-512": 128,
+}
+
+// validateHashAlgorithm rejects an unsupported algorithm name, defaulting
+// an empty one to DefaultHashAlgorithm.
+func validateHashAlgorithm(algorithm string) (string, error) {
+	if algorithm == "" {
+		algorithm = DefaultHashAlgorithm
+	}
+	if _, ok := hexDigestLength[algorithm]; !ok {
+		return "", fmt.Errorf("unsupported hash algorithm %q", algorithm)
+	}
+	return algorithm, nil
+}
+
+// validateHashFormat rejects a hash string whose length doesn't match what
+// algorithm is expected to produce, encoded as hex.
+func validateHashFormat(algorithm, hash string) error {
+	expected, ok := hexDigestLength[algorithm]
+	if !ok {
+		return fmt.Errorf("unsupported hash algorithm %q", algorithm)
+	}
+	if len(hash) != expected {
+		return fmt.Errorf("hash %q has length %d, expected %d hex characters for %s", hash, len(hash), expected, algorithm)
+	}
+	return nil
+}
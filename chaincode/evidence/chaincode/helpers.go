@@ -0,0 +1,220 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// maxIDLength is the longest value validateID accepts for an identifier
+// field. It's generous enough for any real case/evidence/custodian ID while
+// keeping composite state keys (and query selectors built from them) bounded.
+const maxIDLength = 128
+
+// idPattern restricts identifier fields to characters that are safe to
+// embed directly in composite state keys and CouchDB selectors.
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// validateID enforces that an identifier field is non-empty, within
+// maxIDLength, and made up only of alphanumerics, dashes, and underscores.
+// name identifies the field in the returned error so callers can report
+// precisely which argument was malformed.
+func validateID(name, value string) error {
+	if value == "" {
+		return fmt.Errorf("%s must not be empty", name)
+	}
+	if len(value) > maxIDLength {
+		return fmt.Errorf("%s exceeds the maximum length of %d characters", name, maxIDLength)
+	}
+	if !idPattern.MatchString(value) {
+		return fmt.Errorf("%s may only contain letters, digits, dashes, and underscores", name)
+	}
+	return nil
+}
+
+func evidenceKey(caseID, evidenceID string) string {
+	return fmt.Sprintf("%s%s_%s", evidenceKeyPrefix, caseID, evidenceID)
+}
+
+func custodianKey(id string) string {
+	return custodianKeyPrefix + id
+}
+
+// eventArchiveKey addresses one segment of an evidence item's compacted
+// event history. segment numbers from 1, matching EventArchiveRecord.Segment,
+// so each CompactEventHistory call appends a new segment instead of
+// overwriting the last one.
+func eventArchiveKey(caseID, evidenceID string, segment int) string {
+	return fmt.Sprintf("%s%s_%s_%d", eventArchiveKeyPrefix, caseID, evidenceID, segment)
+}
+
+func invalidationKey(caseID, evidenceID, txID string) string {
+	return fmt.Sprintf("%s%s_%s_%s", invalidationKeyPrefix, caseID, evidenceID, txID)
+}
+
+// getTxTimestamp returns the transaction's timestamp as RFC3339 UTC, so that
+// all endorsing peers agree on "now" regardless of wall-clock skew.
+func getTxTimestamp(ctx contractapi.TransactionContextInterface) (string, error) {
+	ts, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return "", fmt.Errorf("failed to read transaction timestamp: %v", err)
+	}
+	return time.Unix(ts.Seconds, int64(ts.Nanos)).UTC().Format(time.RFC3339Nano), nil
+}
+
+// normalizeTimestamp parses s as RFC3339 (any UTC offset) and re-emits it in
+// canonical UTC RFC3339Nano, the same format getTxTimestamp produces. Every
+// time argument a caller supplies and every comparison against a stored
+// timestamp should go through this first, so a client in a non-UTC timezone
+// can't desync string comparisons against timestamps we stored in UTC.
+func normalizeTimestamp(s string) (string, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return "", fmt.Errorf("invalid timestamp %q: must be RFC3339: %v", s, err)
+	}
+	return t.UTC().Format(time.RFC3339Nano), nil
+}
+
+// getClientOrgMSP returns the MSP ID of the submitting client's organization.
+func getClientOrgMSP(ctx contractapi.TransactionContextInterface) (string, error) {
+	orgMSP, err := ctx.GetClientIdentity().GetMSPID()
+	if err != nil {
+		return "", fmt.Errorf("failed to read client MSP ID: %v", err)
+	}
+	return orgMSP, nil
+}
+
+// newCustodyEvent builds a CustodyEvent for the current transaction,
+// populating the fields every mutator needs to set consistently (TxID,
+// timestamp, submitting org, and the best-effort endorsing-org set).
+func newCustodyEvent(ctx contractapi.TransactionContextInterface, eventType, actor, fromOwner, toOwner, reason string) (CustodyEvent, error) {
+	if err := checkCertValidity(ctx); err != nil {
+		return CustodyEvent{}, err
+	}
+	ts, err := getTxTimestamp(ctx)
+	if err != nil {
+		return CustodyEvent{}, err
+	}
+	orgMSP, err := getClientOrgMSP(ctx)
+	if err != nil {
+		return CustodyEvent{}, err
+	}
+	return CustodyEvent{
+		TxID:          ctx.GetStub().GetTxID(),
+		EventType:     eventType,
+		Timestamp:     ts,
+		Actor:         actor,
+		FromOwner:     fromOwner,
+		ToOwner:       toOwner,
+		Reason:        reason,
+		OrgMSP:        orgMSP,
+		EndorsingOrgs: []string{orgMSP},
+	}, nil
+}
+
+// putEvidence marshals and writes an evidence record keyed by case+evidence
+// ID, first checking the break-glass global freeze and then asserting the
+// write doesn't violate append-only custody event semantics (see
+// assertAppendOnly).
+func putEvidence(ctx contractapi.TransactionContextInterface, e *Evidence) error {
+	if err := checkGlobalFreeze(ctx); err != nil {
+		return err
+	}
+	key := evidenceKey(e.CaseID, e.EvidenceID)
+	if err := assertAppendOnly(ctx, key, e); err != nil {
+		return err
+	}
+	bytes, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal evidence: %v", err)
+	}
+	return ctx.GetStub().PutState(key, bytes)
+}
+
+// assertAppendOnly rejects a write that would drop or reorder custody
+// events already committed for key, guarding against a buggy mutator
+// truncating or rewriting history instead of only ever appending to it.
+// CompactEventHistory is the one sanctioned exception: it moves events out
+// of the live slice into a hash-chained archive and records that by
+// increasing ArchivedEventCount, which this check treats as proof the
+// "missing" events were relocated, not lost. RepairMissingTxIDs is the
+// other: eventsEqualModuloTxIDBackfill lets it fill in a blank TxID left by
+// an early bug without otherwise touching the event.
+func assertAppendOnly(ctx contractapi.TransactionContextInterface, key string, newEvidence *Evidence) error {
+	existing, err := ctx.GetStub().GetState(key)
+	if err != nil {
+		return fmt.Errorf("failed to read existing evidence for append-only check: %v", err)
+	}
+	if existing == nil {
+		return nil
+	}
+	var old Evidence
+	if err := json.Unmarshal(existing, &old); err != nil {
+		return fmt.Errorf("failed to unmarshal existing evidence for append-only check: %v", err)
+	}
+	if newEvidence.ArchivedEventCount > old.ArchivedEventCount {
+		return nil
+	}
+	if len(newEvidence.Events) < len(old.Events) {
+		return fmt.Errorf("rejected write to %s: new events slice has %d entries, fewer than the %d already stored; custody events must never be removed", key, len(newEvidence.Events), len(old.Events))
+	}
+	for i, oldEvent := range old.Events {
+		if !eventsEqualModuloTxIDBackfill(oldEvent, newEvidence.Events[i]) {
+			return fmt.Errorf("rejected write to %s: stored event at index %d would be altered; custody history must never be reordered or rewritten", key, i)
+		}
+	}
+	return nil
+}
+
+// eventsEqualModuloTxIDBackfill reports whether new is identical to old, or
+// differs only in that old.TxID was blank and new.TxID fills it in. It
+// never allows a non-blank TxID to change.
+func eventsEqualModuloTxIDBackfill(old, new CustodyEvent) bool {
+	if old.TxID == "" && new.TxID != "" {
+		old.TxID = new.TxID
+	}
+	return reflect.DeepEqual(old, new)
+}
+
+// notFoundError is returned by getEvidence when no record exists for the
+// given key, so callers can distinguish "not found" from other failures
+// without string-matching error messages.
+type notFoundError struct {
+	caseID, evidenceID string
+}
+
+func (e *notFoundError) Error() string {
+	return fmt.Sprintf("evidence %s not found in case %s", e.evidenceID, e.caseID)
+}
+
+func isNotFoundError(err error) bool {
+	_, ok := err.(*notFoundError)
+	return ok
+}
+
+// getEvidence is the shared "get state, check nil, unmarshal" helper so every
+// public function returns a consistent not-found error.
+func getEvidence(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) (*Evidence, error) {
+	if err := validateID("caseID", caseID); err != nil {
+		return nil, err
+	}
+	if err := validateID("evidenceID", evidenceID); err != nil {
+		return nil, err
+	}
+	bytes, err := ctx.GetStub().GetState(evidenceKey(caseID, evidenceID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read evidence %s/%s: %v", caseID, evidenceID, err)
+	}
+	if bytes == nil {
+		return nil, &notFoundError{caseID: caseID, evidenceID: evidenceID}
+	}
+	var e Evidence
+	if err := json.Unmarshal(bytes, &e); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal evidence %s/%s: %v", caseID, evidenceID, err)
+	}
+	return &e, nil
+}
@@ -0,0 +1,123 @@
+package chaincode
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// processingLockTTLKey is the configurable number of seconds a
+// ProcessingLock may stand before ExpireStaleHolds considers it orphaned.
+// Zero (the default) disables auto-expiry, since a client crash mid-external-
+// processing shouldn't silently release evidence without an operator
+// explicitly opting into a TTL.
+const processingLockTTLKey = "POLICY_PROCESSING_LOCK_TTL_SECONDS"
+
+// SetProcessingLockTTL configures how many seconds a ProcessingLock may
+// stand before ExpireStaleHolds releases it as orphaned. Zero disables
+// auto-expiry.
+func (s *SmartContract) SetProcessingLockTTL(ctx contractapi.TransactionContextInterface, seconds int) error {
+	if seconds < 0 {
+		return fmt.Errorf("seconds must be non-negative")
+	}
+	return ctx.GetStub().PutState(processingLockTTLKey, []byte(strconv.Itoa(seconds)))
+}
+
+func processingLockTTLSeconds(ctx contractapi.TransactionContextInterface) (int, error) {
+	bytes, err := ctx.GetStub().GetState(processingLockTTLKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read processing lock TTL policy: %v", err)
+	}
+	if bytes == nil {
+		return 0, nil
+	}
+	seconds, err := strconv.Atoi(string(bytes))
+	if err != nil {
+		return 0, fmt.Errorf("corrupt processing lock TTL policy: %v", err)
+	}
+	return seconds, nil
+}
+
+// ExpireStaleHolds releases orphaned ProcessingLocks (older than
+// SetProcessingLockTTL, if configured) and checkouts past their
+// ExpectedReturn, as of asOf. Each release is recorded as an AUTO_EXPIRED
+// event. With the default TTL of zero, ProcessingLocks are never released
+// here, so one left by a crashed client stays until it is unlocked.
+// Intended to be invoked periodically by an off-chain sweeper.
+func (s *SmartContract) ExpireStaleHolds(ctx contractapi.TransactionContextInterface, asOf string) (*BatchResult, error) {
+	now, err := normalizeTimestamp(asOf)
+	if err != nil {
+		return nil, err
+	}
+	lockTTL, err := processingLockTTLSeconds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	evidences, err := getQueryResultForQueryString(ctx, `{"selector":{"docType":"evidence"}}`)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newBatchResult()
+	for _, e := range evidences {
+		key := e.CaseID + "_" + e.EvidenceID
+		expiredLock, err := processingLockIsStale(e, now, lockTTL)
+		if err != nil {
+			result.Failed[key] = err.Error()
+			continue
+		}
+		expiredCheckout := e.CheckedOut && e.ExpectedReturn != "" && now > e.ExpectedReturn
+		if !expiredLock && !expiredCheckout {
+			continue
+		}
+
+		detail := ""
+		if expiredLock {
+			detail = fmt.Sprintf("processing lock held by %s since %s auto-expired", e.ProcessingLock.HolderID, e.ProcessingLock.LockedAt)
+			e.ProcessingLock = nil
+		}
+		if expiredCheckout {
+			if detail != "" {
+				detail += "; "
+			}
+			detail += fmt.Sprintf("checkout by %s past expected return %s auto-expired", e.CheckedOutBy, e.ExpectedReturn)
+			e.CheckedOut = false
+			e.CheckedOutBy = ""
+			e.CheckOutPurpose = ""
+			e.ExpectedReturn = ""
+		}
+
+		event, err := newCustodyEvent(ctx, EventAutoExpired, e.CurrentOwner, "", "", detail)
+		if err != nil {
+			result.Failed[key] = err.Error()
+			continue
+		}
+		e.UpdatedAt = event.Timestamp
+		e.Events = append(e.Events, event)
+		if err := putEvidence(ctx, e); err != nil {
+			result.Failed[key] = err.Error()
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, key)
+	}
+
+	return result, nil
+}
+
+func processingLockIsStale(e *Evidence, now string, ttlSeconds int) (bool, error) {
+	if e.ProcessingLock == nil || ttlSeconds <= 0 {
+		return false, nil
+	}
+	lockedAt, err := time.Parse(time.RFC3339Nano, e.ProcessingLock.LockedAt)
+	if err != nil {
+		return false, fmt.Errorf("corrupt ProcessingLock.LockedAt on evidence %s: %v", e.EvidenceID, err)
+	}
+	nowTime, err := time.Parse(time.RFC3339Nano, now)
+	if err != nil {
+		return false, err
+	}
+	return nowTime.After(lockedAt.Add(time.Duration(ttlSeconds) * time.Second)), nil
+}
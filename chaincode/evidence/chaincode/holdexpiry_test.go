@@ -0,0 +1,89 @@
+package chaincode
+
+import (
+	"testing"
+	"time"
+)
+
+// addSeconds adds n seconds to an RFC3339Nano timestamp, for nudging a
+// ProcessingLock's LockedAt past a TTL in tests.
+func addSeconds(ts string, n int) string {
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		panic(err)
+	}
+	return t.Add(time.Duration(n) * time.Second).Format(time.RFC3339)
+}
+
+func TestExpireStaleHolds_ReleasesLockPastTTL(t *testing.T) {
+	ctx := newMockCtx()
+	sc := &SmartContract{}
+	if err := sc.SetStateDBType(ctx, "leveldb"); err != nil {
+		t.Fatalf("SetStateDBType failed: %v", err)
+	}
+	mustCreate(t, ctx, sc, "case-1", "ev-1")
+
+	if err := sc.LockForProcessing(ctx, "case-1", "ev-1", "lab-ref-1"); err != nil {
+		t.Fatalf("LockForProcessing failed: %v", err)
+	}
+	if err := sc.SetProcessingLockTTL(ctx, 60); err != nil {
+		t.Fatalf("SetProcessingLockTTL failed: %v", err)
+	}
+
+	e, err := getEvidence(ctx, "case-1", "ev-1")
+	if err != nil {
+		t.Fatalf("getEvidence failed: %v", err)
+	}
+	lockedAt := e.ProcessingLock.LockedAt
+
+	result, err := sc.ExpireStaleHolds(ctx, addSeconds(lockedAt, 120))
+	if err != nil {
+		t.Fatalf("ExpireStaleHolds failed: %v", err)
+	}
+	if len(result.Succeeded) != 1 || result.Succeeded[0] != "case-1_ev-1" {
+		t.Fatalf("expected case-1_ev-1 to be released, got %+v", result)
+	}
+
+	e, err = getEvidence(ctx, "case-1", "ev-1")
+	if err != nil {
+		t.Fatalf("getEvidence failed: %v", err)
+	}
+	if e.ProcessingLock != nil {
+		t.Fatalf("expected ProcessingLock to be cleared, got %+v", e.ProcessingLock)
+	}
+}
+
+func TestExpireStaleHolds_ZeroTTLDisablesExpiry(t *testing.T) {
+	ctx := newMockCtx()
+	sc := &SmartContract{}
+	if err := sc.SetStateDBType(ctx, "leveldb"); err != nil {
+		t.Fatalf("SetStateDBType failed: %v", err)
+	}
+	mustCreate(t, ctx, sc, "case-1", "ev-1")
+
+	if err := sc.LockForProcessing(ctx, "case-1", "ev-1", "lab-ref-1"); err != nil {
+		t.Fatalf("LockForProcessing failed: %v", err)
+	}
+
+	e, err := getEvidence(ctx, "case-1", "ev-1")
+	if err != nil {
+		t.Fatalf("getEvidence failed: %v", err)
+	}
+	lockedAt := e.ProcessingLock.LockedAt
+
+	result, err := sc.ExpireStaleHolds(ctx, addSeconds(lockedAt, 120))
+	if err != nil {
+		t.Fatalf("ExpireStaleHolds failed: %v", err)
+	}
+	if len(result.Succeeded) != 0 {
+		t.Fatalf("expected no holds released with TTL disabled, got %+v", result)
+	}
+
+	e, err = getEvidence(ctx, "case-1", "ev-1")
+	if err != nil {
+		t.Fatalf("getEvidence failed: %v", err)
+	}
+	if e.ProcessingLock == nil {
+		t.Fatalf("expected ProcessingLock to remain set with TTL disabled")
+	}
+}
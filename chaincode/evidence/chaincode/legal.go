@@ -0,0 +1,46 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// ExpungeContent blanks the content reference (CID and CIDs) and the hash
+// (Hash and PartHashes) of an evidence item under a court order, while leaving the custody
+// chain and metadata intact for audit. The item must not be under legal
+// hold, and the call is restricted to supervisors.
+func (s *SmartContract) ExpungeContent(ctx contractapi.TransactionContextInterface, caseID, evidenceID, courtOrderRef string) error {
+	if err := requireSupervisor(ctx); err != nil {
+		return err
+	}
+	if courtOrderRef == "" {
+		return fmt.Errorf("courtOrderRef is required")
+	}
+
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if e.LegalHold {
+		return fmt.Errorf("evidence %s is under legal hold and cannot be expunged", evidenceID)
+	}
+	if e.ContentExpunged {
+		return fmt.Errorf("evidence %s content has already been expunged", evidenceID)
+	}
+
+	event, err := newCustodyEvent(ctx, EventExpunge, e.CurrentOwner, "", "", fmt.Sprintf("court order %s", courtOrderRef))
+	if err != nil {
+		return err
+	}
+
+	e.CID = ""
+	e.Hash = ""
+	e.CIDs = nil
+	e.PartHashes = nil
+	e.ContentExpunged = true
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return putEvidence(ctx, e)
+}
@@ -0,0 +1,107 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// RecordDerivedEvidence establishes that derivedEvidenceID (e.g. a file
+// carved out of a disk image) was produced from parentEvidenceID, both
+// within the same case. This lineage is what InvalidateEvidence's cascade
+// option follows when the parent turns out to be tampered.
+func (s *SmartContract) RecordDerivedEvidence(ctx contractapi.TransactionContextInterface, caseID, parentEvidenceID, derivedEvidenceID string) error {
+	if err := validateID("derivedEvidenceID", derivedEvidenceID); err != nil {
+		return err
+	}
+	if parentEvidenceID == derivedEvidenceID {
+		return fmt.Errorf("evidence cannot be derived from itself")
+	}
+	parent, err := getEvidence(ctx, caseID, parentEvidenceID)
+	if err != nil {
+		return err
+	}
+	derived, err := getEvidence(ctx, caseID, derivedEvidenceID)
+	if err != nil {
+		return fmt.Errorf("derived evidence must already be registered: %v", err)
+	}
+
+	alreadyLinked := false
+	for _, id := range parent.DerivedEvidenceIDs {
+		if id == derivedEvidenceID {
+			alreadyLinked = true
+			break
+		}
+	}
+	if !alreadyLinked {
+		parent.DerivedEvidenceIDs = append(parent.DerivedEvidenceIDs, derivedEvidenceID)
+		if err := putEvidence(ctx, parent); err != nil {
+			return err
+		}
+	}
+
+	if derived.ParentEvidenceID == parentEvidenceID {
+		return nil
+	}
+	derived.ParentEvidenceID = parentEvidenceID
+	return putEvidence(ctx, derived)
+}
+
+// LineageIssue describes one broken or one-sided parent/child reference
+// found by AuditLineageIntegrity.
+type LineageIssue struct {
+	EvidenceID string `json:"evidenceID"`
+	Issue      string `json:"issue"`
+}
+
+// AuditLineageIntegrity checks every evidence item in a case whose
+// DerivedEvidenceIDs or ParentEvidenceID references another item, verifying
+// that reference resolves to an existing record and is reciprocated on the
+// other side. Run this after merges, invalidation cascades, or migrations
+// to catch referential corruption those bulk operations might have left
+// behind.
+func (s *SmartContract) AuditLineageIntegrity(ctx contractapi.TransactionContextInterface, caseID string) ([]LineageIssue, error) {
+	evidences, err := s.QueryEvidencesByCase(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*Evidence, len(evidences))
+	for _, e := range evidences {
+		byID[e.EvidenceID] = e
+	}
+
+	var issues []LineageIssue
+	for _, e := range evidences {
+		for _, childID := range e.DerivedEvidenceIDs {
+			child, ok := byID[childID]
+			if !ok {
+				issues = append(issues, LineageIssue{EvidenceID: e.EvidenceID, Issue: fmt.Sprintf("derived evidence %s does not exist in this case", childID)})
+				continue
+			}
+			if child.ParentEvidenceID != e.EvidenceID {
+				issues = append(issues, LineageIssue{EvidenceID: e.EvidenceID, Issue: fmt.Sprintf("derived evidence %s does not point back to %s as its parent", childID, e.EvidenceID)})
+			}
+		}
+		if e.ParentEvidenceID == "" {
+			continue
+		}
+		parent, ok := byID[e.ParentEvidenceID]
+		if !ok {
+			issues = append(issues, LineageIssue{EvidenceID: e.EvidenceID, Issue: fmt.Sprintf("parent evidence %s does not exist in this case", e.ParentEvidenceID)})
+			continue
+		}
+		reciprocated := false
+		for _, childID := range parent.DerivedEvidenceIDs {
+			if childID == e.EvidenceID {
+				reciprocated = true
+				break
+			}
+		}
+		if !reciprocated {
+			issues = append(issues, LineageIssue{EvidenceID: e.EvidenceID, Issue: fmt.Sprintf("parent %s does not list %s among its derived evidence", e.ParentEvidenceID, e.EvidenceID)})
+		}
+	}
+
+	return issues, nil
+}
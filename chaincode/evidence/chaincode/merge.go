@@ -0,0 +1,75 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// MergeEvidence folds a duplicate record (the same physical item registered
+// twice by mistake) into a canonical one. Both event histories are
+// preserved in full: the canonical record's Events keeps its own committed
+// history untouched (assertAppendOnly forbids reordering it) with the
+// duplicate's chain concatenated on afterward, followed by a trailing MERGE
+// event. Each source chain stays internally chronological, so a reader can
+// still reconstruct "what happened, and to which physical registration" in
+// order; only the cross-chain interleaving of two originally-independent
+// histories is lost, which is the price of never rewriting committed
+// events. The duplicate itself is marked StatusMerged pointing at the
+// canonical ID rather than deleted, so the original mistake stays auditable.
+// Supervisor-only, since this rewrites which record is authoritative for a
+// piece of evidence.
+func (s *SmartContract) MergeEvidence(ctx contractapi.TransactionContextInterface, caseID, canonicalID, duplicateID, reason string) error {
+	if err := requireSupervisor(ctx); err != nil {
+		return err
+	}
+	if err := validateID("canonicalID", canonicalID); err != nil {
+		return err
+	}
+	if err := validateID("duplicateID", duplicateID); err != nil {
+		return err
+	}
+	if canonicalID == duplicateID {
+		return fmt.Errorf("canonicalID and duplicateID must refer to different evidence")
+	}
+	if reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+
+	canonical, err := getEvidence(ctx, caseID, canonicalID)
+	if err != nil {
+		return err
+	}
+	if err := validateTransition(canonical, "merge into", []string{StatusActive, StatusArchived, StatusQuarantined}, "the canonical record must not already be invalidated or merged"); err != nil {
+		return err
+	}
+
+	duplicate, err := getEvidence(ctx, caseID, duplicateID)
+	if err != nil {
+		return err
+	}
+	if err := validateTransition(duplicate, "merge", []string{StatusActive, StatusArchived, StatusQuarantined}, "an invalidated or already-merged record cannot be merged"); err != nil {
+		return err
+	}
+
+	canonical.Events = append(canonical.Events, duplicate.Events...)
+	canonicalEvent, err := newCustodyEvent(ctx, EventMerge, canonical.CurrentOwner, "", "", fmt.Sprintf("merged duplicate %s into %s: %s", duplicateID, canonicalID, reason))
+	if err != nil {
+		return err
+	}
+	canonical.Events = append(canonical.Events, canonicalEvent)
+	canonical.UpdatedAt = canonicalEvent.Timestamp
+	if err := putEvidence(ctx, canonical); err != nil {
+		return err
+	}
+
+	duplicateEvent, err := newCustodyEvent(ctx, EventMerge, duplicate.CurrentOwner, "", canonicalID, fmt.Sprintf("merged into canonical %s: %s", canonicalID, reason))
+	if err != nil {
+		return err
+	}
+	duplicate.Events = append(duplicate.Events, duplicateEvent)
+	duplicate.Status = StatusMerged
+	duplicate.MergedInto = canonicalID
+	duplicate.UpdatedAt = duplicateEvent.Timestamp
+	return putEvidence(ctx, duplicate)
+}
@@ -0,0 +1,109 @@
+package chaincode
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// MerkleExport is a deterministic Merkle tree over one case's evidence
+// hashes, for archival verification independent of Fabric. Levels[0] holds
+// the leaves (in the same order as Leaves); each subsequent level holds
+// that level's parent hashes, ending with Levels[len(Levels)-1] holding
+// just Root.
+type MerkleExport struct {
+	CaseID string     `json:"caseID"`
+	Leaves []string   `json:"leaves"`
+	Levels [][]string `json:"levels"`
+	Root   string     `json:"root"`
+}
+
+// MerkleProofStep is one sibling hash on the path from a leaf to the root,
+// and which side of the pair it sits on.
+type MerkleProofStep struct {
+	Hash     string `json:"hash"`
+	Position string `json:"position"` // "left" or "right"
+}
+
+func merkleLeafHash(e *Evidence) string {
+	sum := sha256.Sum256([]byte(e.EvidenceID + ":" + e.Hash))
+	return hex.EncodeToString(sum[:])
+}
+
+func merkleParentHash(left, right string) string {
+	sum := sha256.Sum256([]byte(left + right))
+	return hex.EncodeToString(sum[:])
+}
+
+// buildMerkleLevels folds leaves up to a single root, pairing adjacent
+// hashes at each level. An odd hash left over at the end of a level
+// carries up unchanged rather than being paired with itself, so the tree
+// stays deterministic without inventing padding.
+func buildMerkleLevels(leaves []string) [][]string {
+	levels := [][]string{leaves}
+	current := leaves
+	for len(current) > 1 {
+		next := make([]string, 0, (len(current)+1)/2)
+		for i := 0; i < len(current); i += 2 {
+			if i+1 < len(current) {
+				next = append(next, merkleParentHash(current[i], current[i+1]))
+			} else {
+				next = append(next, current[i])
+			}
+		}
+		levels = append(levels, next)
+		current = next
+	}
+	return levels
+}
+
+// ExportCaseMerkleTree returns the ordered leaf hashes, every intermediate
+// level, and the signed-able root hash for a case's evidence, computed
+// deterministically (evidence in EvidenceID order) so re-running this later
+// against the same data reproduces the same root.
+func (s *SmartContract) ExportCaseMerkleTree(ctx contractapi.TransactionContextInterface, caseID string) (*MerkleExport, error) {
+	evidences, err := s.QueryEvidencesByCase(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	leaves := make([]string, len(evidences))
+	for i, e := range evidences {
+		leaves[i] = merkleLeafHash(e)
+	}
+
+	export := &MerkleExport{CaseID: caseID, Leaves: leaves}
+	if len(leaves) == 0 {
+		export.Levels = [][]string{{}}
+		return export, nil
+	}
+	export.Levels = buildMerkleLevels(leaves)
+	export.Root = export.Levels[len(export.Levels)-1][0]
+	return export, nil
+}
+
+// VerifyMerkleProof confirms that leafHash is included under rootHash by
+// folding proof (a path of sibling hashes from ExportCaseMerkleTree) back
+// up to the root and comparing, without needing the rest of the dataset.
+func (s *SmartContract) VerifyMerkleProof(ctx contractapi.TransactionContextInterface, leafHash, proofJSON, rootHash string) (bool, error) {
+	var proof []MerkleProofStep
+	if err := json.Unmarshal([]byte(proofJSON), &proof); err != nil {
+		return false, fmt.Errorf("invalid proof JSON: %v", err)
+	}
+
+	current := leafHash
+	for _, step := range proof {
+		switch step.Position {
+		case "left":
+			current = merkleParentHash(step.Hash, current)
+		case "right":
+			current = merkleParentHash(current, step.Hash)
+		default:
+			return false, fmt.Errorf("proof step position must be 'left' or 'right', got %q", step.Position)
+		}
+	}
+	return current == rootHash, nil
+}
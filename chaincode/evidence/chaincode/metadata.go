@@ -0,0 +1,115 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// GetEvidenceMetadataField returns a single metadata value by key, so a list
+// view that only needs, say, "extractedText" or "exifCameraModel" doesn't
+// have to pull the full Metadata map (which can carry large embedded text
+// dumps) just to read one field.
+func (s *SmartContract) GetEvidenceMetadataField(ctx contractapi.TransactionContextInterface, caseID, evidenceID, fieldPath string) (string, error) {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return "", err
+	}
+	value, ok := e.Metadata[fieldPath]
+	if !ok {
+		return "", fmt.Errorf("evidence %s has no metadata field %q", evidenceID, fieldPath)
+	}
+	return value, nil
+}
+
+// requiredMetadataKeysKey stores the configured set of metadata keys every
+// evidence record must carry. An unset/empty set means no key is mandatory.
+const requiredMetadataKeysKey = "POLICY_REQUIRED_METADATA_KEYS"
+
+// SetRequiredMetadataKeys configures the metadata keys UpdateMetadata must
+// find present and non-empty; CreateEvidence does not check them. Passing
+// an empty list removes the restriction.
+func (s *SmartContract) SetRequiredMetadataKeys(ctx contractapi.TransactionContextInterface, keysJSON string) error {
+	var keys []string
+	if err := json.Unmarshal([]byte(keysJSON), &keys); err != nil {
+		return fmt.Errorf("invalid required metadata keys JSON: %v", err)
+	}
+	bytes, err := json.Marshal(keys)
+	if err != nil {
+		return fmt.Errorf("failed to marshal required metadata keys: %v", err)
+	}
+	return ctx.GetStub().PutState(requiredMetadataKeysKey, bytes)
+}
+
+func (s *SmartContract) getRequiredMetadataKeys(ctx contractapi.TransactionContextInterface) ([]string, error) {
+	bytes, err := ctx.GetStub().GetState(requiredMetadataKeysKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read required metadata keys: %v", err)
+	}
+	if bytes == nil {
+		return nil, nil
+	}
+	var keys []string
+	if err := json.Unmarshal(bytes, &keys); err != nil {
+		return nil, fmt.Errorf("corrupt required metadata keys: %v", err)
+	}
+	return keys, nil
+}
+
+// missingRequiredMetadataKeys returns, in configured order, every required
+// key that is absent or empty in metadata.
+func missingRequiredMetadataKeys(required []string, metadata map[string]string) []string {
+	var missing []string
+	for _, key := range required {
+		if metadata[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+// UpdateMetadata replaces evidenceID's metadata map wholesale, rejecting
+// the update if it would drop any key SetRequiredMetadataKeys has declared
+// mandatory (whether by omitting it or setting it empty), so an operator
+// can't accidentally wipe a record's required descriptive fields through
+// an edit. Returns the list of keys that would have been lost.
+func (s *SmartContract) UpdateMetadata(ctx contractapi.TransactionContextInterface, caseID, evidenceID, metadataJSON string) ([]string, error) {
+	var metadata map[string]string
+	if err := json.Unmarshal([]byte(metadataJSON), &metadata); err != nil {
+		return nil, fmt.Errorf("invalid metadata JSON: %v", err)
+	}
+
+	required, err := s.getRequiredMetadataKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lost := missingRequiredMetadataKeys(required, metadata)
+	if len(lost) > 0 {
+		sort.Strings(lost)
+		return lost, fmt.Errorf("update to evidence %s would drop required metadata key(s): %v", evidenceID, lost)
+	}
+
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireNotLockedForProcessing(e); err != nil {
+		return nil, err
+	}
+	if err := requireNotEmbargoed(ctx, e); err != nil {
+		return nil, err
+	}
+
+	event, err := newCustodyEvent(ctx, EventMetadataUpdate, e.CurrentOwner, "", "", "metadata updated")
+	if err != nil {
+		return nil, err
+	}
+
+	e.Metadata = metadata
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return nil, putEvidence(ctx, e)
+}
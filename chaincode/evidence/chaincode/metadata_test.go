@@ -0,0 +1,38 @@
+package chaincode
+
+import "testing"
+
+func TestUpdateMetadata_HappyPath(t *testing.T) {
+	ctx := newMockCtx()
+	sc := &SmartContract{}
+	mustCreate(t, ctx, sc, "case-1", "ev-1")
+
+	if _, err := sc.UpdateMetadata(ctx, "case-1", "ev-1", `{"examiner":"alice"}`); err != nil {
+		t.Fatalf("UpdateMetadata failed: %v", err)
+	}
+	e, err := getEvidence(ctx, "case-1", "ev-1")
+	if err != nil {
+		t.Fatalf("getEvidence failed: %v", err)
+	}
+	if e.Metadata["examiner"] != "alice" {
+		t.Fatalf("expected metadata to be replaced, got %v", e.Metadata)
+	}
+}
+
+func TestUpdateMetadata_RejectsDroppingRequiredKey(t *testing.T) {
+	ctx := newMockCtx()
+	sc := &SmartContract{}
+	mustCreate(t, ctx, sc, "case-1", "ev-1")
+
+	if err := sc.SetRequiredMetadataKeys(ctx, `["examiner","tool"]`); err != nil {
+		t.Fatalf("SetRequiredMetadataKeys failed: %v", err)
+	}
+
+	lost, err := sc.UpdateMetadata(ctx, "case-1", "ev-1", `{"examiner":""}`)
+	if err == nil {
+		t.Fatalf("expected update dropping required key to be rejected")
+	}
+	if len(lost) != 2 || lost[0] != "examiner" || lost[1] != "tool" {
+		t.Fatalf("expected both required keys reported missing, got %v", lost)
+	}
+}
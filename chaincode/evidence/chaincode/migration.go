@@ -0,0 +1,75 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// applyDefaults fills in fields introduced after an evidence record was
+// originally written, so older records behave consistently with new ones.
+func applyDefaults(e *Evidence) {
+	if e.Metadata == nil {
+		e.Metadata = map[string]string{}
+	}
+}
+
+// MigrateEvidence upgrades a single evidence record to CurrentSchemaVersion,
+// filling in defaults for fields that didn't exist when it was created, and
+// recording a MIGRATE event. It is a no-op if the record is already current.
+func (s *SmartContract) MigrateEvidence(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) error {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if e.SchemaVersion >= CurrentSchemaVersion {
+		return nil
+	}
+
+	applyDefaults(e)
+
+	fromVersion := e.SchemaVersion
+	event, err := newCustodyEvent(ctx, EventMigrate, "system", "", "", fmt.Sprintf("schema v%d -> v%d", fromVersion, CurrentSchemaVersion))
+	if err != nil {
+		return err
+	}
+
+	e.SchemaVersion = CurrentSchemaVersion
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return putEvidence(ctx, e)
+}
+
+// MigrateAll upgrades every out-of-date evidence record in a case, in
+// batches, so large cases can be migrated without a single oversized
+// transaction. It returns the IDs successfully migrated and any failures.
+func (s *SmartContract) MigrateAll(ctx contractapi.TransactionContextInterface, caseID string, pageSize int) (*BatchResult, error) {
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+
+	evidences, err := s.QueryEvidencesByCase(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newBatchResult()
+	migrated := 0
+	for _, e := range evidences {
+		if e.SchemaVersion >= CurrentSchemaVersion {
+			continue
+		}
+		if migrated >= pageSize {
+			break
+		}
+		if err := s.MigrateEvidence(ctx, e.CaseID, e.EvidenceID); err != nil {
+			result.Failed[e.EvidenceID] = err.Error()
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, e.EvidenceID)
+		migrated++
+	}
+
+	return result, nil
+}
@@ -0,0 +1,120 @@
+package chaincode
+
+import (
+	"sort"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+)
+
+// mockClientIdentity is a minimal cid.ClientIdentity for tests that don't
+// care about real certificate-backed identity.
+type mockClientIdentity struct {
+	cid.ClientIdentity
+	mspID string
+}
+
+func (m *mockClientIdentity) GetMSPID() (string, error) {
+	return m.mspID, nil
+}
+
+func (m *mockClientIdentity) GetID() (string, error) {
+	return "test-identity", nil
+}
+
+// mockStub is a minimal in-memory ChaincodeStubInterface covering only the
+// operations this package's tests exercise. Embedding the interface lets it
+// satisfy the rest without implementing every method.
+type mockStub struct {
+	shim.ChaincodeStubInterface
+	state map[string][]byte
+	txID  string
+}
+
+func newMockStub() *mockStub {
+	return &mockStub{state: map[string][]byte{}, txID: "tx-1"}
+}
+
+func (m *mockStub) GetState(key string) ([]byte, error) {
+	return m.state[key], nil
+}
+
+func (m *mockStub) PutState(key string, value []byte) error {
+	m.state[key] = value
+	return nil
+}
+
+// mockRangeIterator is a minimal in-memory StateQueryIteratorInterface over a
+// pre-sorted slice of key/value pairs, enough for the range scan
+// scanAllEvidenceWithSelector performs on a LevelDB-backed peer.
+type mockRangeIterator struct {
+	items []*queryresult.KV
+	pos   int
+}
+
+func (it *mockRangeIterator) HasNext() bool {
+	return it.pos < len(it.items)
+}
+
+func (it *mockRangeIterator) Next() (*queryresult.KV, error) {
+	item := it.items[it.pos]
+	it.pos++
+	return item, nil
+}
+
+func (it *mockRangeIterator) Close() error {
+	return nil
+}
+
+func (m *mockStub) GetStateByRange(startKey, endKey string) (shim.StateQueryIteratorInterface, error) {
+	var keys []string
+	for key := range m.state {
+		if key >= startKey && (endKey == "" || key < endKey) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	items := make([]*queryresult.KV, 0, len(keys))
+	for _, key := range keys {
+		items = append(items, &queryresult.KV{Key: key, Value: m.state[key]})
+	}
+	return &mockRangeIterator{items: items}, nil
+}
+
+func (m *mockStub) GetTxID() string {
+	return m.txID
+}
+
+func (m *mockStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
+	return &timestamp.Timestamp{Seconds: 1700000000}, nil
+}
+
+func (m *mockStub) GetChannelID() string {
+	return "test-channel"
+}
+
+func (m *mockStub) SetEvent(name string, payload []byte) error {
+	return nil
+}
+
+// mockCtx is a minimal TransactionContextInterface wrapping mockStub.
+type mockCtx struct {
+	contractapi.TransactionContextInterface
+	stub *mockStub
+}
+
+func newMockCtx() *mockCtx {
+	return &mockCtx{stub: newMockStub()}
+}
+
+func (m *mockCtx) GetStub() shim.ChaincodeStubInterface {
+	return m.stub
+}
+
+func (m *mockCtx) GetClientIdentity() cid.ClientIdentity {
+	return &mockClientIdentity{mspID: "Org1MSP"}
+}
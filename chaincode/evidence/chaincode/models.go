@@ -0,0 +1,453 @@
+package chaincode
+
+// Evidence lifecycle statuses.
+const (
+	StatusActive         = "ACTIVE"
+	StatusArchived       = "ARCHIVED"
+	StatusInvalidated    = "INVALIDATED"
+	StatusQuarantined    = "QUARANTINED"
+	StatusMerged         = "MERGED"
+	StatusTransferredOut = "TRANSFERRED_OUT"
+)
+
+// Custody event types recorded in an evidence's append-only history.
+const (
+	EventCreate               = "CREATE"
+	EventTransfer             = "TRANSFER"
+	EventArchive              = "ARCHIVE"
+	EventReactivate           = "REACTIVATE"
+	EventInvalidate           = "INVALIDATE"
+	EventVerify               = "VERIFY"
+	EventAnnotate             = "ANNOTATE"
+	EventExpunge              = "EXPUNGE"
+	EventMigrate              = "MIGRATE"
+	EventDispute              = "DISPUTE"
+	EventResolve              = "RESOLVE_DISPUTE"
+	EventReclassify           = "RECLASSIFY"
+	EventTransferRevoked      = "TRANSFER_REVOKED"
+	EventMerge                = "MERGE"
+	EventCheckOut             = "CHECK_OUT"
+	EventCheckIn              = "CHECK_IN"
+	EventReactivationRequest  = "REACTIVATION_REQUESTED"
+	EventReactivationApproved = "REACTIVATION_APPROVED"
+	EventOutboundTransfer     = "OUTBOUND_TRANSFER"
+	EventInboundTransfer      = "INBOUND_TRANSFER"
+	EventRedact               = "REDACT"
+	EventUnredact             = "UNREDACT"
+	EventAdmit                = "ADMIT_TO_PROCEEDING"
+	EventStorageMove          = "STORAGE_MOVE"
+	EventTag                  = "TAG"
+	EventLock                 = "LOCKED_FOR_PROCESSING"
+	EventUnlock               = "UNLOCKED_FROM_PROCESSING"
+	EventCaseSplit            = "CASE_SPLIT"
+	EventEmbargo              = "EMBARGO"
+	EventLiftEmbargo          = "LIFT_EMBARGO"
+	EventAutoExpired          = "AUTO_EXPIRED"
+	EventMetadataUpdate       = "METADATA_UPDATE"
+)
+
+// Evidence kinds distinguish an original acquisition from the forensic or
+// working copies examiners actually handle day to day.
+const (
+	EvidenceKindOriginal     = "ORIGINAL"
+	EvidenceKindForensicCopy = "FORENSIC_COPY"
+	EvidenceKindWorkingCopy  = "WORKING_COPY"
+)
+
+// CurrentSchemaVersion is the Evidence schema version written by this build
+// of the chaincode. Records written before SchemaVersion existed have it
+// unset (zero value) and must be upgraded via MigrateEvidence.
+const CurrentSchemaVersion = 1
+
+// State key prefixes. Evidence keys are namespaced by case so that case-scoped
+// range/rich queries stay cheap.
+const (
+	evidenceKeyPrefix     = "EVIDENCE_"
+	custodianKeyPrefix    = "CUSTODIAN_"
+	eventArchiveKeyPrefix = "EVENTS_ARCHIVE_"
+	invalidationKeyPrefix = "INVALIDATION_"
+	examinerKeyPrefix     = "EXAMINER_"
+)
+
+// CustodyEvent is one entry in an Evidence's append-only custody trail.
+type CustodyEvent struct {
+	TxID      string `json:"txID"`
+	EventType string `json:"eventType"`
+	Timestamp string `json:"timestamp"`
+	Actor     string `json:"actor"`
+	FromOwner string `json:"fromOwner,omitempty"`
+	ToOwner   string `json:"toOwner,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	OrgMSP    string `json:"orgMSP"`
+
+	// ReasonCode is the standardized code behind Reason's free-text detail,
+	// for event types whose reason taxonomy is enforced (see SetReasonCodes).
+	ReasonCode string `json:"reasonCode,omitempty"`
+
+	// Location records where a field handoff took place, when captured by
+	// TransferCustodyWithLocation. Nil for events that don't carry one.
+	Location *Location `json:"location,omitempty"`
+
+	// EndorsingOrgs records organizations known to have endorsed this write.
+	// The chaincode cannot see the full signed proposal's endorsement set at
+	// execution time (endorsements are only finalized after simulation), so
+	// this is best-effort: it holds only the submitting org, and no function
+	// adds further orgs to it.
+	EndorsingOrgs []string `json:"endorsingOrgs"`
+
+	// ExaminerID and CredentialRef identify the certified examiner who
+	// performed this action, for expert-witness qualification. Optional:
+	// empty when the action wasn't performed by a registered examiner.
+	ExaminerID    string `json:"examinerID,omitempty"`
+	CredentialRef string `json:"credentialRef,omitempty"`
+
+	// WitnessID identifies who was physically present to co-sign a transfer,
+	// digitizing the paper witness requirement. Optional unless
+	// SetRequireWitness has been enabled for the channel.
+	WitnessID string `json:"witnessID,omitempty"`
+
+	// Channel records the counterpart Fabric channel for a cross-channel
+	// transfer: the destination on an OUTBOUND_TRANSFER event, the source
+	// on an INBOUND_TRANSFER event.
+	Channel string `json:"channel,omitempty"`
+
+	// VerifyPassed is set on VERIFY events to record whether the provided
+	// hash matched, so QueryFailedVerifications can find items whose most
+	// recent integrity check failed without re-deriving it from Reason.
+	VerifyPassed bool `json:"verifyPassed,omitempty"`
+}
+
+// Evidence is the on-chain record for a single piece of evidence.
+type Evidence struct {
+	DocType      string            `json:"docType"`
+	CaseID       string            `json:"caseID"`
+	EvidenceID   string            `json:"evidenceID"`
+	Hash         string            `json:"hash"`
+	CID          string            `json:"cid"`
+	Metadata     map[string]string `json:"metadata"`
+	Status       string            `json:"status"`
+	CurrentOwner string            `json:"currentOwner"`
+	OrgMSP       string            `json:"orgMSP"`
+	CreatedBy    string            `json:"createdBy"`
+	CreatedAt    string            `json:"createdAt"`
+	UpdatedAt    string            `json:"updatedAt"`
+	Events       []CustodyEvent    `json:"events"`
+
+	LegalHold       bool `json:"legalHold"`
+	ContentExpunged bool `json:"contentExpunged"`
+
+	// Disputed freezes transfers and archival pending a ruling on a defense
+	// motion, independent of LegalHold. Verification and annotation remain
+	// allowed so the parties can keep examining the item while it's frozen.
+	Disputed   bool   `json:"disputed"`
+	DisputeRef string `json:"disputeRef,omitempty"`
+
+	SchemaVersion int `json:"schemaVersion"`
+
+	ContentType string `json:"contentType"`
+
+	TransferCount int `json:"transferCount"`
+
+	PerceptualHash string `json:"perceptualHash,omitempty"`
+
+	// HashAlgorithm is the digest algorithm Hash (and, for multi-part
+	// items, PartHashes) was computed with. Defaults to SHA-256.
+	HashAlgorithm string `json:"hashAlgorithm,omitempty"`
+
+	// ArchivedEventCount, ArchivedSegmentCount, and EventArchiveHash describe
+	// events that have been compacted out of Events by CompactEventHistory.
+	// Each call appends a new EventArchiveRecord segment rather than
+	// overwriting the last one; ArchivedSegmentCount is how many segments
+	// exist (see eventArchiveKey), and EventArchiveHash is the hash of the
+	// most recent one, so GetFullCustodyChain and auditors can walk the
+	// chain and verify it hasn't been tampered with.
+	ArchivedEventCount   int    `json:"archivedEventCount,omitempty"`
+	ArchivedSegmentCount int    `json:"archivedSegmentCount,omitempty"`
+	EventArchiveHash     string `json:"eventArchiveHash,omitempty"`
+
+	// AttachedDocuments holds the hashes of physical/paper records (custody
+	// forms, warrants, etc.) anchored alongside the digital evidence.
+	AttachedDocuments []AttachedDocument `json:"attachedDocuments,omitempty"`
+
+	// CIDs holds every part of a multi-part item (large disk images split
+	// across several IPFS CIDs). CID is kept populated with CIDs[0] for
+	// callers that only know about the single-CID model.
+	CIDs []string `json:"cids,omitempty"`
+
+	// PartHashes holds the per-part hash for each entry in CIDs, in the
+	// same order, for multi-part items created via CreateMultipartEvidence.
+	PartHashes []string `json:"partHashes,omitempty"`
+
+	// Classification is the sensitivity tier set at creation (ROUTINE,
+	// SENSITIVE, or CLASSIFIED). Some operations require the caller to carry
+	// a matching or higher clearance cert attribute; see requireClearance.
+	Classification string `json:"classification,omitempty"`
+
+	// DerivedEvidenceIDs lists evidence (in the same case) produced from this
+	// item, e.g. files carved out of a disk image. Recorded via
+	// RecordDerivedEvidence. InvalidateEvidence's cascade option follows this
+	// lineage to invalidate everything derived from a tampered parent.
+	DerivedEvidenceIDs []string `json:"derivedEvidenceIDs,omitempty"`
+
+	// MergedInto holds the canonical evidence ID this record was folded into
+	// by MergeEvidence. Set only when Status is StatusMerged; a merged record
+	// keeps its own event history, and operations gated on status (transfer,
+	// archival, reactivation, invalidation, checkout) reject it, while ones
+	// that don't check status, such as AddAnnotation, AddTag and
+	// UpdateMetadata, still apply to it.
+	MergedInto string `json:"mergedInto,omitempty"`
+
+	// CheckedOut and the fields below track physical examination lab
+	// checkout, set by CheckOutEvidence and cleared by CheckInEvidence.
+	// Transfers are blocked while CheckedOut is true.
+	CheckedOut      bool   `json:"checkedOut,omitempty"`
+	CheckedOutBy    string `json:"checkedOutBy,omitempty"`
+	CheckOutPurpose string `json:"checkOutPurpose,omitempty"`
+	ExpectedReturn  string `json:"expectedReturn,omitempty"`
+
+	// PendingReactivation holds an outstanding reactivation request when
+	// SetReactivationRequiresApproval is enabled. Set by ReactivateFromCold,
+	// cleared by ApproveReactivation once a distinct supervisor signs off.
+	PendingReactivation *ReactivationRequest `json:"pendingReactivation,omitempty"`
+
+	// OracleRecords holds authoritative data posted by an external system
+	// (e.g. a LIMS result) via PostOracleRecord. Append-only: custodians
+	// cannot alter or remove an entry once the configured oracle MSP has
+	// posted it.
+	OracleRecords []OracleRecord `json:"oracleRecords,omitempty"`
+
+	// OutboundChannel and OutboundTransferToken are set by
+	// CrossChannelTransfer when this evidence left the channel for a
+	// multi-jurisdiction handoff; Status becomes StatusTransferredOut.
+	OutboundChannel       string `json:"outboundChannel,omitempty"`
+	OutboundTransferToken string `json:"outboundTransferToken,omitempty"`
+
+	// SourceChannel and SourceTransferToken are set by CrossChannelReceive,
+	// pointing back at the channel and transfer token this evidence
+	// originated from, for cross-channel provenance.
+	SourceChannel       string `json:"sourceChannel,omitempty"`
+	SourceTransferToken string `json:"sourceTransferToken,omitempty"`
+
+	// RetentionUntil is the date this evidence is due for archival, set via
+	// SetRetentionUntil. Used by GetCaseRetentionCompliance to flag overdue
+	// items; ignored for items under LegalHold.
+	RetentionUntil string `json:"retentionUntil,omitempty"`
+
+	// ParentEvidenceID is the reciprocal of DerivedEvidenceIDs: set on a
+	// derived item by RecordDerivedEvidence to point back at the item it was
+	// produced from. AuditLineageIntegrity checks the two stay consistent.
+	ParentEvidenceID string `json:"parentEvidenceID,omitempty"`
+
+	// Admissions records every court proceeding this evidence has been
+	// admitted into, via AdmitToProceeding.
+	Admissions []Admission `json:"admissions,omitempty"`
+
+	// StorageLocation is the physical facility currently holding archived
+	// evidence, updated by RecordStorageMove. Only meaningful while Status
+	// is StatusArchived.
+	StorageLocation string `json:"storageLocation,omitempty"`
+
+	// Tags are free-form labels attached via AddTag, for ad hoc curation
+	// (e.g. marking items for a specific review pass) outside the formal
+	// classification/status model.
+	Tags []string `json:"tags,omitempty"`
+
+	// ProcessingLock, when set, blocks the mutators listed on
+	// requireNotLockedForProcessing until UnlockFromProcessing (or
+	// ExpireStaleHolds) clears it, for the duration evidence is out at an external
+	// lab. Distinct from CheckedOut (physical lab custody) and Disputed
+	// (frozen pending a ruling): this is a software-level exclusion lock.
+	ProcessingLock *ProcessingLock `json:"processingLock,omitempty"`
+
+	// LinkedCaseIDs lists cases (other than CaseID) that also reference this
+	// evidence, set by SplitCase when a case splits and the new case needs
+	// to cite existing evidence without duplicating the underlying record.
+	LinkedCaseIDs []string `json:"linkedCaseIDs,omitempty"`
+
+	// Annotations are free-text notes attached via AddAnnotation. Entries
+	// are never removed, only marked Withdrawn by WithdrawAnnotation, so the
+	// record stays append-only while still letting a mistaken or sensitive
+	// note be taken out of ordinary view.
+	Annotations []Annotation `json:"annotations,omitempty"`
+
+	// EvidenceKind distinguishes an original physical/digital acquisition
+	// from forensic or working copies examiners actually operate on. Set at
+	// creation; see EvidenceKindOriginal and friends.
+	EvidenceKind string `json:"evidenceKind,omitempty"`
+
+	// OriginalEvidenceID links a FORENSIC_COPY or WORKING_COPY (in the same
+	// case) back to the ORIGINAL it was made from. Empty for an ORIGINAL.
+	OriginalEvidenceID string `json:"originalEvidenceID,omitempty"`
+
+	// EmbargoUntil, when set by SetEmbargo, makes GetEvidence return a stub
+	// and blocks the mutators listed on requireNotEmbargoed until the
+	// transaction timestamp passes it; see embargo.go.
+	EmbargoUntil string `json:"embargoUntil,omitempty"`
+
+	// EmbargoReason records why SetEmbargo was invoked, e.g. a grand jury
+	// seal order.
+	EmbargoReason string `json:"embargoReason,omitempty"`
+
+	// TimestampToken is an RFC 3161 timestamp token obtained off-chain at
+	// creation time and anchored here for extra-strong temporal provenance.
+	// The chaincode only sanity-checks its format and embedded time (see
+	// VerifyTimestampToken); full TSA chain-of-trust verification is left
+	// to an off-chain verifier with the TSA's certificate.
+	TimestampToken string `json:"timestampToken,omitempty"`
+
+	// AcquisitionTool and AcquisitionToolVersion document which imaging
+	// tool acquired the evidence (e.g. "EnCase", "21.4"), set at creation.
+	// See SetRequireAcquisitionTool and QueryEvidencesByTool.
+	AcquisitionTool        string `json:"acquisitionTool,omitempty"`
+	AcquisitionToolVersion string `json:"acquisitionToolVersion,omitempty"`
+}
+
+// Annotation is one free-text note attached to an evidence item via
+// AddAnnotation.
+type Annotation struct {
+	Text      string `json:"text"`
+	Author    string `json:"author"`
+	CreatedAt string `json:"createdAt"`
+
+	// Withdrawn, once set by WithdrawAnnotation, means Text has been replaced
+	// with "[withdrawn]" in this public record; the original is moved into
+	// withdrawnAnnotationCollection, readable only by a supervisor via
+	// GetWithdrawnAnnotationText.
+	Withdrawn       bool   `json:"withdrawn,omitempty"`
+	WithdrawnBy     string `json:"withdrawnBy,omitempty"`
+	WithdrawnReason string `json:"withdrawnReason,omitempty"`
+	WithdrawnAt     string `json:"withdrawnAt,omitempty"`
+}
+
+// ProcessingLock records who locked evidence for external processing, and
+// under what reference, so only that identity (or a supervisor) can release
+// it via UnlockFromProcessing.
+type ProcessingLock struct {
+	HolderID      string `json:"holderID"`
+	ProcessingRef string `json:"processingRef"`
+	LockedAt      string `json:"lockedAt"`
+}
+
+// Admission links an evidence item to a specific court proceeding it was
+// admitted into, via AdmitToProceeding.
+type Admission struct {
+	ProceedingRef string `json:"proceedingRef"`
+	AdmittedBy    string `json:"admittedBy"`
+	AdmittedAt    string `json:"admittedAt"`
+}
+
+// OracleRecord is one authoritative record posted by an external system,
+// carrying that system's signature over its payload so the source can be
+// verified independently of Fabric's own endorsement.
+type OracleRecord struct {
+	Source          string `json:"source"`
+	Payload         string `json:"payload"`
+	SourceSignature string `json:"sourceSignature"`
+	SubmittedAt     string `json:"submittedAt"`
+	TxID            string `json:"txID"`
+}
+
+// ReactivationRequest records who asked for archived evidence to be brought
+// back, and why, pending a distinct supervisor's approval.
+type ReactivationRequest struct {
+	RequestedBy string `json:"requestedBy"`
+	Reason      string `json:"reason"`
+	RequestedAt string `json:"requestedAt"`
+}
+
+// EventArchiveRecord is a compacted-out slice of an evidence's older custody
+// events, stored off the hot Evidence record under a per-segment key (see
+// eventArchiveKey). Segment numbers its position in the chain starting at 1,
+// and PriorHash chains each archive to the one before it (empty for the
+// first), so the full archive history can be verified link by link.
+type EventArchiveRecord struct {
+	DocType    string         `json:"docType"`
+	CaseID     string         `json:"caseID"`
+	EvidenceID string         `json:"evidenceID"`
+	Segment    int            `json:"segment"`
+	Events     []CustodyEvent `json:"events"`
+	PriorHash  string         `json:"priorHash,omitempty"`
+	Hash       string         `json:"hash"`
+}
+
+// AttachedDocument anchors the hash of a physical/paper record (a signed
+// custody form, warrant, etc.) that accompanies the digital evidence.
+type AttachedDocument struct {
+	DocumentType string `json:"documentType"`
+	DocHash      string `json:"docHash"`
+	DocCID       string `json:"docCID,omitempty"`
+}
+
+// EvidenceSummary is the lightweight projection used by list views that
+// don't need the full custody history.
+type EvidenceSummary struct {
+	CaseID       string `json:"caseID"`
+	EvidenceID   string `json:"evidenceID"`
+	Status       string `json:"status"`
+	CurrentOwner string `json:"currentOwner"`
+	CreatedAt    string `json:"createdAt"`
+	UpdatedAt    string `json:"updatedAt"`
+}
+
+// InvalidationRecord is a standalone audit trail entry written alongside an
+// INVALIDATE event, keyed separately from the Evidence record so the fact
+// "this evidence was invalidated, by whom, and why" survives independently
+// of whatever later happens to the Evidence document itself.
+type InvalidationRecord struct {
+	DocType    string `json:"docType"`
+	CaseID     string `json:"caseID"`
+	EvidenceID string `json:"evidenceID"`
+	TxID       string `json:"txID"`
+	Timestamp  string `json:"timestamp"`
+	ReasonCode string `json:"reasonCode"`
+	Detail     string `json:"detail,omitempty"`
+}
+
+// Location is the geographic point where a field handoff was recorded.
+type Location struct {
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	LocationName string  `json:"locationName,omitempty"`
+}
+
+// Examiner is a registered, certified individual whose examinerID and
+// credentialRef can be attached to custody events for expert-witness
+// qualification.
+type Examiner struct {
+	DocType       string `json:"docType"`
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	CredentialRef string `json:"credentialRef"`
+	Active        bool   `json:"active"`
+}
+
+// Custodian is a registered holder of evidence custody.
+type Custodian struct {
+	DocType string `json:"docType"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	OrgMSP  string `json:"orgMSP"`
+	Active  bool   `json:"active"`
+}
+
+// BatchResult reports the outcome of an operation applied across many
+// evidence items, so a single failure doesn't hide the rest of the results.
+type BatchResult struct {
+	Succeeded []string          `json:"succeeded"`
+	Failed    map[string]string `json:"failed"`
+}
+
+func newBatchResult() *BatchResult {
+	return &BatchResult{Failed: map[string]string{}}
+}
+
+func toSummary(e *Evidence) *EvidenceSummary {
+	return &EvidenceSummary{
+		CaseID:       e.CaseID,
+		EvidenceID:   e.EvidenceID,
+		Status:       e.Status,
+		CurrentOwner: e.CurrentOwner,
+		CreatedAt:    e.CreatedAt,
+		UpdatedAt:    e.UpdatedAt,
+	}
+}
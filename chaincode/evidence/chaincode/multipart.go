@@ -0,0 +1,130 @@
+package chaincode
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// EvidenceParts is the CIDs/PartHashes projection returned by
+// GetEvidenceParts for multi-part evidence.
+type EvidenceParts struct {
+	CaseID     string   `json:"caseID"`
+	EvidenceID string   `json:"evidenceID"`
+	CIDs       []string `json:"cids"`
+	PartHashes []string `json:"partHashes"`
+}
+
+// combinedPartHash returns a single SHA-256 digest over every part hash in
+// order, so a multi-part item still has one value to cite as "the hash" of
+// the whole item.
+func combinedPartHash(partHashes []string) string {
+	hasher := sha256.New()
+	for _, h := range partHashes {
+		hasher.Write([]byte(h))
+	}
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// CreateMultipartEvidence registers a new evidence item whose content is
+// split across multiple IPFS CIDs (e.g. a large disk image), with a hash
+// recorded per part. The stored Hash is the combined hash over every part
+// hash in order, and CID/CIDs[0] hold the first part for callers still on
+// the single-CID model.
+func (s *SmartContract) CreateMultipartEvidence(ctx contractapi.TransactionContextInterface, caseID, evidenceID, cidsJSON, partHashesJSON, metadataJSON, custodianID, contentType string) error {
+	if err := validateID("custodianID", custodianID); err != nil {
+		return err
+	}
+
+	var cids []string
+	if err := json.Unmarshal([]byte(cidsJSON), &cids); err != nil {
+		return fmt.Errorf("invalid cids JSON: %v", err)
+	}
+	var partHashes []string
+	if err := json.Unmarshal([]byte(partHashesJSON), &partHashes); err != nil {
+		return fmt.Errorf("invalid partHashes JSON: %v", err)
+	}
+	if len(cids) == 0 {
+		return fmt.Errorf("cids must not be empty")
+	}
+	if len(cids) != len(partHashes) {
+		return fmt.Errorf("cids and partHashes must have the same length, got %d and %d", len(cids), len(partHashes))
+	}
+
+	exists, err := s.EvidenceExists(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("evidence %s already exists in case %s", evidenceID, caseID)
+	}
+	if err := s.validateContentType(ctx, contentType); err != nil {
+		return err
+	}
+
+	metadata := map[string]string{}
+	if metadataJSON != "" {
+		if err := json.Unmarshal([]byte(metadataJSON), &metadata); err != nil {
+			return fmt.Errorf("invalid metadata JSON: %v", err)
+		}
+	}
+
+	ts, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+	orgMSP, err := getClientOrgMSP(ctx)
+	if err != nil {
+		return err
+	}
+	event, err := newCustodyEvent(ctx, EventCreate, custodianID, "", custodianID, "")
+	if err != nil {
+		return err
+	}
+
+	evidence := &Evidence{
+		DocType:        "evidence",
+		CaseID:         caseID,
+		EvidenceID:     evidenceID,
+		Hash:           combinedPartHash(partHashes),
+		CID:            cids[0],
+		CIDs:           cids,
+		PartHashes:     partHashes,
+		Metadata:       metadata,
+		Status:         StatusActive,
+		CurrentOwner:   custodianID,
+		OrgMSP:         orgMSP,
+		CreatedBy:      custodianID,
+		CreatedAt:      ts,
+		UpdatedAt:      ts,
+		SchemaVersion:  CurrentSchemaVersion,
+		ContentType:    contentType,
+		HashAlgorithm:  DefaultHashAlgorithm,
+		Classification: ClassificationRoutine,
+		Events:         []CustodyEvent{event},
+	}
+
+	return putEvidence(ctx, evidence)
+}
+
+// GetEvidenceParts enumerates the CIDs and per-part hashes of a multi-part
+// evidence item. The CIDs point directly at the underlying content, so this
+// requires clearance matching the evidence's classification.
+func (s *SmartContract) GetEvidenceParts(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) (*EvidenceParts, error) {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireClearance(ctx, e.Classification); err != nil {
+		return nil, err
+	}
+	return &EvidenceParts{
+		CaseID:     caseID,
+		EvidenceID: evidenceID,
+		CIDs:       e.CIDs,
+		PartHashes: e.PartHashes,
+	}, nil
+}
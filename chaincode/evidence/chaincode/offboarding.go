@@ -0,0 +1,102 @@
+package chaincode
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// QueryEvidencesByOrg returns every evidence record an org has any stake
+// in: items it created (orgMSP) plus items currently held by one of its
+// registered custodians, so an offboarding review doesn't miss evidence
+// that changed hands after creation.
+func (s *SmartContract) QueryEvidencesByOrg(ctx contractapi.TransactionContextInterface, orgMSP string) ([]*EvidenceSummary, error) {
+	if orgMSP == "" {
+		return nil, fmt.Errorf("orgMSP is required")
+	}
+
+	queryString, err := buildSelectorQuery(map[string]interface{}{"docType": "evidence", "orgMSP": orgMSP})
+	if err != nil {
+		return nil, err
+	}
+	created, err := getQueryResultForQueryString(ctx, queryString)
+	if err != nil {
+		return nil, err
+	}
+
+	custodians, err := s.ListCustodians(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var summaries []*EvidenceSummary
+	for _, e := range created {
+		key := e.CaseID + "_" + e.EvidenceID
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		summaries = append(summaries, toSummary(e))
+	}
+	for _, custodian := range custodians {
+		if custodian.OrgMSP != orgMSP {
+			continue
+		}
+		held, err := s.QueryEvidencesByOwner(ctx, custodian.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range held {
+			key := e.CaseID + "_" + e.EvidenceID
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			summaries = append(summaries, toSummary(e))
+		}
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].EvidenceID != summaries[j].EvidenceID {
+			return summaries[i].EvidenceID < summaries[j].EvidenceID
+		}
+		return summaries[i].CreatedAt < summaries[j].CreatedAt
+	})
+	return summaries, nil
+}
+
+// BulkReassignOrgEvidence transfers every active evidence item an org holds
+// (see QueryEvidencesByOrg) to a successor custodian, for consortium
+// membership changes where a departing org must hand off everything it
+// holds. Supervisor-only.
+func (s *SmartContract) BulkReassignOrgEvidence(ctx contractapi.TransactionContextInterface, departingOrgMSP, successorCustodian, reason string) (*BatchResult, error) {
+	if err := requireSupervisor(ctx); err != nil {
+		return nil, err
+	}
+	if err := validateID("successorCustodian", successorCustodian); err != nil {
+		return nil, err
+	}
+
+	owned, err := s.QueryEvidencesByOrg(ctx, departingOrgMSP)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newBatchResult()
+	transferReason := fmt.Sprintf("BULK REASSIGN (org offboarding %s): %s", departingOrgMSP, reason)
+	for _, summary := range owned {
+		if summary.Status != StatusActive {
+			continue
+		}
+		key := summary.CaseID + "_" + summary.EvidenceID
+		if err := s.TransferCustody(ctx, summary.CaseID, summary.EvidenceID, successorCustodian, transferReason, "", "", ""); err != nil {
+			result.Failed[key] = err.Error()
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, key)
+	}
+
+	return result, nil
+}
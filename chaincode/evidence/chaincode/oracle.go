@@ -0,0 +1,131 @@
+package chaincode
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// oracleMSPKey stores the single org MSP ID permitted to call
+// PostOracleRecord, the external system integration (e.g. a LIMS) that
+// posts data custodians can't alter.
+const oracleMSPKey = "POLICY_ORACLE_MSP"
+
+// SetOracleMSP designates the org MSP permitted to call PostOracleRecord. It
+// can only be set once by bootstrap, or rotated by the current oracle org,
+// mirroring SetAdminMSP so no other org can silently grant itself oracle
+// authority.
+func (s *SmartContract) SetOracleMSP(ctx contractapi.TransactionContextInterface, mspID string) error {
+	if mspID == "" {
+		return fmt.Errorf("mspID is required")
+	}
+	current, err := ctx.GetStub().GetState(oracleMSPKey)
+	if err != nil {
+		return fmt.Errorf("failed to read oracle MSP: %v", err)
+	}
+	if current != nil {
+		callerMSP, err := getClientOrgMSP(ctx)
+		if err != nil {
+			return err
+		}
+		if callerMSP != string(current) {
+			return fmt.Errorf("only the current oracle org (%s) may change the oracle MSP", string(current))
+		}
+	}
+	return ctx.GetStub().PutState(oracleMSPKey, []byte(mspID))
+}
+
+// requireOracleMSP rejects the call unless it comes from the configured
+// oracle org.
+func requireOracleMSP(ctx contractapi.TransactionContextInterface) error {
+	oracleMSP, err := ctx.GetStub().GetState(oracleMSPKey)
+	if err != nil {
+		return fmt.Errorf("failed to read oracle MSP: %v", err)
+	}
+	if oracleMSP == nil {
+		return fmt.Errorf("no oracle MSP configured; call SetOracleMSP first")
+	}
+	callerMSP, err := getClientOrgMSP(ctx)
+	if err != nil {
+		return err
+	}
+	if callerMSP != string(oracleMSP) {
+		return fmt.Errorf("caller org %s is not the configured oracle org", callerMSP)
+	}
+	return nil
+}
+
+// PostOracleRecord appends an authoritative record from an external system
+// (e.g. a lab result from the LIMS) to evidence's OracleRecords. Restricted
+// to the configured oracle MSP, and append-only like the rest of the
+// custody trail: nothing it writes can later be edited by a custodian.
+func (s *SmartContract) PostOracleRecord(ctx contractapi.TransactionContextInterface, caseID, evidenceID, source, payload, sourceSignature string) error {
+	if err := requireOracleMSP(ctx); err != nil {
+		return err
+	}
+	if source == "" || payload == "" || sourceSignature == "" {
+		return fmt.Errorf("source, payload, and sourceSignature are required")
+	}
+
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+
+	ts, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+	record := OracleRecord{
+		Source:          source,
+		Payload:         payload,
+		SourceSignature: sourceSignature,
+		SubmittedAt:     ts,
+		TxID:            ctx.GetStub().GetTxID(),
+	}
+	e.OracleRecords = append(e.OracleRecords, record)
+	e.UpdatedAt = ts
+	return putEvidence(ctx, e)
+}
+
+// GetOracleRecords returns every oracle record posted for an evidence item,
+// in the order they were posted.
+func (s *SmartContract) GetOracleRecords(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) ([]OracleRecord, error) {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return nil, err
+	}
+	return e.OracleRecords, nil
+}
+
+// VerifyOracleRecordSignature checks an OracleRecord's SourceSignature
+// against the oracle's ECDSA public key: sourceSignature must be a
+// base64-encoded ASN.1 ECDSA signature over sha256("<source>|<payload>"),
+// and publicKeyPEM the PEM-encoded public key the oracle signed it with.
+func VerifyOracleRecordSignature(record OracleRecord, publicKeyPEM string) (bool, error) {
+	block, _ := pem.Decode([]byte(publicKeyPEM))
+	if block == nil {
+		return false, fmt.Errorf("publicKeyPEM does not contain a PEM block")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse public key: %v", err)
+	}
+	ecdsaKey, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return false, fmt.Errorf("public key is not an ECDSA key")
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(record.SourceSignature)
+	if err != nil {
+		return false, fmt.Errorf("sourceSignature is not valid base64: %v", err)
+	}
+
+	digest := sha256.Sum256([]byte(record.Source + "|" + record.Payload))
+	return ecdsa.VerifyASN1(ecdsaKey, digest[:], signature), nil
+}
@@ -0,0 +1,79 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// OwnershipAnomaly flags an evidence item whose recorded ownership looks
+// inconsistent, for DetectOwnershipAnomalies.
+type OwnershipAnomaly struct {
+	EvidenceID string `json:"evidenceID"`
+	Detail     string `json:"detail"`
+}
+
+// DetectOwnershipAnomalies checks every evidence item in a case for
+// consistency between CurrentOwner, the actor/recipient on its latest
+// TRANSFER (or CREATE, if never transferred) event, and, for owners that are
+// registered custodians, OrgMSP. A mismatch usually means the record was
+// corrupted by a bug, or patched directly in state outside the normal
+// mutators, rather than an intentional custody change.
+func (s *SmartContract) DetectOwnershipAnomalies(ctx contractapi.TransactionContextInterface, caseID string) ([]OwnershipAnomaly, error) {
+	evidences, err := s.QueryEvidencesByCase(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	var anomalies []OwnershipAnomaly
+	for _, e := range evidences {
+		if e.CurrentOwner == "" {
+			anomalies = append(anomalies, OwnershipAnomaly{EvidenceID: e.EvidenceID, Detail: "currentOwner is unset"})
+			continue
+		}
+		if e.OrgMSP == "" {
+			anomalies = append(anomalies, OwnershipAnomaly{EvidenceID: e.EvidenceID, Detail: "orgMSP is unset"})
+		}
+
+		var lastOwnershipEvent *CustodyEvent
+		for i := len(e.Events) - 1; i >= 0; i-- {
+			event := e.Events[i]
+			if event.EventType == EventTransfer || event.EventType == EventCreate {
+				lastOwnershipEvent = &e.Events[i]
+				break
+			}
+		}
+		if lastOwnershipEvent == nil {
+			anomalies = append(anomalies, OwnershipAnomaly{EvidenceID: e.EvidenceID, Detail: "no CREATE or TRANSFER event establishes current ownership"})
+			continue
+		}
+
+		var recordedOwner string
+		switch lastOwnershipEvent.EventType {
+		case EventTransfer:
+			recordedOwner = lastOwnershipEvent.ToOwner
+		case EventCreate:
+			recordedOwner = lastOwnershipEvent.Actor
+		}
+		if recordedOwner != e.CurrentOwner {
+			anomalies = append(anomalies, OwnershipAnomaly{
+				EvidenceID: e.EvidenceID,
+				Detail:     fmt.Sprintf("currentOwner %q does not match %s event's recipient %q", e.CurrentOwner, lastOwnershipEvent.EventType, recordedOwner),
+			})
+		}
+
+		custodian, err := s.GetCustodian(ctx, e.CurrentOwner)
+		if err != nil {
+			// CurrentOwner isn't a registered custodian, so there's no
+			// independent org record to cross-check OrgMSP against.
+			continue
+		}
+		if custodian.OrgMSP != e.OrgMSP {
+			anomalies = append(anomalies, OwnershipAnomaly{
+				EvidenceID: e.EvidenceID,
+				Detail:     fmt.Sprintf("orgMSP %q does not match current owner %s's registered org %q", e.OrgMSP, e.CurrentOwner, custodian.OrgMSP),
+			})
+		}
+	}
+	return anomalies, nil
+}
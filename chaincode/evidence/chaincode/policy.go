@@ -0,0 +1,300 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// allowedContentTypesKey stores the configured content-type allowlist
+// applied to new evidence. An unset/empty allowlist means any non-empty
+// content type is accepted.
+const allowedContentTypesKey = "POLICY_ALLOWED_CONTENT_TYPES"
+
+// SetAllowedContentTypes configures the MIME types CreateEvidence will
+// accept. Passing an empty list removes the restriction.
+func (s *SmartContract) SetAllowedContentTypes(ctx contractapi.TransactionContextInterface, contentTypesJSON string) error {
+	var contentTypes []string
+	if err := json.Unmarshal([]byte(contentTypesJSON), &contentTypes); err != nil {
+		return fmt.Errorf("invalid content types JSON: %v", err)
+	}
+	bytes, err := json.Marshal(contentTypes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal content types: %v", err)
+	}
+	return ctx.GetStub().PutState(allowedContentTypesKey, bytes)
+}
+
+func (s *SmartContract) getAllowedContentTypes(ctx contractapi.TransactionContextInterface) ([]string, error) {
+	bytes, err := ctx.GetStub().GetState(allowedContentTypesKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read content type allowlist: %v", err)
+	}
+	if bytes == nil {
+		return nil, nil
+	}
+	var contentTypes []string
+	if err := json.Unmarshal(bytes, &contentTypes); err != nil {
+		return nil, fmt.Errorf("corrupt content type allowlist: %v", err)
+	}
+	return contentTypes, nil
+}
+
+// validateContentType rejects an empty content type, and, when an allowlist
+// is configured, any type not on it.
+func (s *SmartContract) validateContentType(ctx contractapi.TransactionContextInterface, contentType string) error {
+	if contentType == "" {
+		return fmt.Errorf("contentType is required")
+	}
+	allowed, err := s.getAllowedContentTypes(ctx)
+	if err != nil {
+		return err
+	}
+	if len(allowed) == 0 {
+		return nil
+	}
+	for _, ct := range allowed {
+		if ct == contentType {
+			return nil
+		}
+	}
+	return fmt.Errorf("content type %q is not in the allowed set %v", contentType, allowed)
+}
+
+// maxEventsPolicyKey stores the configured cap on custody events per
+// evidence record. A value of 0 (or unset) means no cap.
+const maxEventsPolicyKey = "POLICY_MAX_EVENTS_PER_EVIDENCE"
+
+// SetMaxEventsPerEvidence configures the maximum number of custody events a
+// single evidence record may accumulate. Once reached, TransferCustody (and
+// the transfers built on it), ArchiveToCold, ReactivateFromCold and
+// TransferAndArchive refuse to add further events; other mutators do not
+// check the cap. INVALIDATE is never capped, so a tampered/flooded item can
+// still be taken out of circulation.
+func (s *SmartContract) SetMaxEventsPerEvidence(ctx contractapi.TransactionContextInterface, max int) error {
+	if max < 0 {
+		return fmt.Errorf("max must be non-negative")
+	}
+	return ctx.GetStub().PutState(maxEventsPolicyKey, []byte(strconv.Itoa(max)))
+}
+
+func (s *SmartContract) getMaxEventsPerEvidence(ctx contractapi.TransactionContextInterface) (int, error) {
+	bytes, err := ctx.GetStub().GetState(maxEventsPolicyKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read max events policy: %v", err)
+	}
+	if bytes == nil {
+		return 0, nil
+	}
+	max, err := strconv.Atoi(string(bytes))
+	if err != nil {
+		return 0, fmt.Errorf("corrupt max events policy value: %v", err)
+	}
+	return max, nil
+}
+
+// reasonCodesKeyPrefix stores the configured reason-code taxonomy per
+// category (e.g. INVALIDATE, ARCHIVE), so reporting isn't defeated by free
+// text like "tampered" vs. "tamper" vs. "evidence tampering".
+const reasonCodesKeyPrefix = "POLICY_REASON_CODES_"
+
+// reasonCodeCategories are the event categories whose reasons can be
+// restricted to a configured code set.
+var reasonCodeCategories = map[string]bool{
+	EventInvalidate: true,
+	EventArchive:    true,
+}
+
+func reasonCodesKey(category string) string {
+	return reasonCodesKeyPrefix + category
+}
+
+// SetReasonCodes configures the allowed reason codes for a category
+// (currently INVALIDATE or ARCHIVE). Passing an empty list removes the
+// restriction, allowing any reason code.
+func (s *SmartContract) SetReasonCodes(ctx contractapi.TransactionContextInterface, category, codesJSON string) error {
+	if !reasonCodeCategories[category] {
+		return fmt.Errorf("unsupported reason code category %q", category)
+	}
+	var codes []string
+	if err := json.Unmarshal([]byte(codesJSON), &codes); err != nil {
+		return fmt.Errorf("invalid reason codes JSON: %v", err)
+	}
+	bytes, err := json.Marshal(codes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal reason codes: %v", err)
+	}
+	return ctx.GetStub().PutState(reasonCodesKey(category), bytes)
+}
+
+func (s *SmartContract) getReasonCodes(ctx contractapi.TransactionContextInterface, category string) ([]string, error) {
+	bytes, err := ctx.GetStub().GetState(reasonCodesKey(category))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reason codes for %s: %v", category, err)
+	}
+	if bytes == nil {
+		return nil, nil
+	}
+	var codes []string
+	if err := json.Unmarshal(bytes, &codes); err != nil {
+		return nil, fmt.Errorf("corrupt reason codes for %s: %v", category, err)
+	}
+	return codes, nil
+}
+
+// validateReasonCode rejects an empty reasonCode, and, when a code set is
+// configured for category, any code not on it.
+func (s *SmartContract) validateReasonCode(ctx contractapi.TransactionContextInterface, category, reasonCode string) error {
+	if reasonCode == "" {
+		return fmt.Errorf("reasonCode is required")
+	}
+	codes, err := s.getReasonCodes(ctx, category)
+	if err != nil {
+		return err
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	for _, code := range codes {
+		if code == reasonCode {
+			return nil
+		}
+	}
+	return fmt.Errorf("reason code %q is not in the allowed set for %s: %v", reasonCode, category, codes)
+}
+
+// minReasonLengthPolicyKey stores the configured minimum length (in
+// non-whitespace characters) for reasons given to InvalidateEvidence,
+// ArchiveToCold, and ReactivateFromCold. Defaults to 10 when unset.
+const minReasonLengthPolicyKey = "POLICY_MIN_REASON_LENGTH"
+
+const defaultMinReasonLength = 10
+
+// SetMinReasonLength configures the minimum non-whitespace length a reason
+// must have for InvalidateEvidence, ArchiveToCold, and ReactivateFromCold.
+// A value of 0 disables the check entirely.
+func (s *SmartContract) SetMinReasonLength(ctx contractapi.TransactionContextInterface, min int) error {
+	if min < 0 {
+		return fmt.Errorf("min must be non-negative")
+	}
+	return ctx.GetStub().PutState(minReasonLengthPolicyKey, []byte(strconv.Itoa(min)))
+}
+
+func (s *SmartContract) getMinReasonLength(ctx contractapi.TransactionContextInterface) (int, error) {
+	bytes, err := ctx.GetStub().GetState(minReasonLengthPolicyKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read min reason length policy: %v", err)
+	}
+	if bytes == nil {
+		return defaultMinReasonLength, nil
+	}
+	min, err := strconv.Atoi(string(bytes))
+	if err != nil {
+		return 0, fmt.Errorf("corrupt min reason length policy value: %v", err)
+	}
+	return min, nil
+}
+
+// validateReasonLength rejects reason if its non-whitespace character count
+// is below the configured minimum for label (used in error messages).
+func (s *SmartContract) validateReasonLength(ctx contractapi.TransactionContextInterface, label, reason string) error {
+	min, err := s.getMinReasonLength(ctx)
+	if err != nil {
+		return err
+	}
+	if min == 0 {
+		return nil
+	}
+	if len(strings.Join(strings.Fields(reason), "")) < min {
+		return fmt.Errorf("%s must contain at least %d non-whitespace characters", label, min)
+	}
+	return nil
+}
+
+// invalidationWindowPolicyKey stores the configured number of days after
+// creation during which only the creating org may invalidate evidence. A
+// value of 0 (or unset) means no restriction: any authorized org may
+// invalidate at any time.
+const invalidationWindowPolicyKey = "POLICY_INVALIDATION_WINDOW_DAYS"
+
+// SetInvalidationWindow configures the number of days after creation during
+// which only evidence.OrgMSP may invalidate an item, giving the creating
+// org a grace period of exclusive control before the wider consortium can
+// act on it. A value of 0 disables the restriction.
+func (s *SmartContract) SetInvalidationWindow(ctx contractapi.TransactionContextInterface, days int) error {
+	if days < 0 {
+		return fmt.Errorf("days must be non-negative")
+	}
+	return ctx.GetStub().PutState(invalidationWindowPolicyKey, []byte(strconv.Itoa(days)))
+}
+
+func (s *SmartContract) getInvalidationWindow(ctx contractapi.TransactionContextInterface) (int, error) {
+	bytes, err := ctx.GetStub().GetState(invalidationWindowPolicyKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read invalidation window policy: %v", err)
+	}
+	if bytes == nil {
+		return 0, nil
+	}
+	days, err := strconv.Atoi(string(bytes))
+	if err != nil {
+		return 0, fmt.Errorf("corrupt invalidation window policy value: %v", err)
+	}
+	return days, nil
+}
+
+// enforceInvalidationWindow rejects invalidation by any org other than
+// e.OrgMSP while the configured window is still open.
+func (s *SmartContract) enforceInvalidationWindow(ctx contractapi.TransactionContextInterface, e *Evidence) error {
+	days, err := s.getInvalidationWindow(ctx)
+	if err != nil {
+		return err
+	}
+	if days == 0 {
+		return nil
+	}
+	callerOrg, err := getClientOrgMSP(ctx)
+	if err != nil {
+		return err
+	}
+	if callerOrg == e.OrgMSP {
+		return nil
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, e.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("corrupt CreatedAt on evidence %s: %v", e.EvidenceID, err)
+	}
+	ts, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+	now, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return err
+	}
+	windowEnds := createdAt.Add(time.Duration(days) * 24 * time.Hour)
+	if now.Before(windowEnds) {
+		return fmt.Errorf("evidence %s may only be invalidated by its creating org (%s) until %s", e.EvidenceID, e.OrgMSP, windowEnds.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// checkEventCap rejects adding another event of eventType to e once the
+// configured cap is reached, unless eventType is EventInvalidate.
+func (s *SmartContract) checkEventCap(ctx contractapi.TransactionContextInterface, e *Evidence, eventType string) error {
+	if eventType == EventInvalidate {
+		return nil
+	}
+	max, err := s.getMaxEventsPerEvidence(ctx)
+	if err != nil {
+		return err
+	}
+	if max > 0 && len(e.Events) >= max {
+		return fmt.Errorf("evidence %s has reached the maximum of %d custody events; archive it instead of appending further events", e.EvidenceID, max)
+	}
+	return nil
+}
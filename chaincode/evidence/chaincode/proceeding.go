@@ -0,0 +1,55 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// AdmitToProceeding records that evidence was admitted into a specific
+// court proceeding, appending an Admission and an ADMIT_TO_PROCEEDING
+// custody event. Blocked on invalidated evidence, since an invalidated
+// item has no business being entered into the record.
+func (s *SmartContract) AdmitToProceeding(ctx contractapi.TransactionContextInterface, caseID, evidenceID, proceedingRef, admittedBy string) error {
+	if proceedingRef == "" {
+		return fmt.Errorf("proceedingRef is required")
+	}
+	if err := validateID("admittedBy", admittedBy); err != nil {
+		return err
+	}
+
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if e.Status == StatusInvalidated {
+		return fmt.Errorf("cannot admit invalidated evidence %s into a proceeding", evidenceID)
+	}
+
+	event, err := newCustodyEvent(ctx, EventAdmit, admittedBy, "", "", fmt.Sprintf("admitted into proceeding %s", proceedingRef))
+	if err != nil {
+		return err
+	}
+
+	e.Admissions = append(e.Admissions, Admission{ProceedingRef: proceedingRef, AdmittedBy: admittedBy, AdmittedAt: event.Timestamp})
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return putEvidence(ctx, e)
+}
+
+// QueryEvidencesByProceeding returns every evidence record admitted into
+// proceedingRef, across all cases.
+func (s *SmartContract) QueryEvidencesByProceeding(ctx contractapi.TransactionContextInterface, proceedingRef string) ([]*Evidence, error) {
+	if proceedingRef == "" {
+		return nil, fmt.Errorf("proceedingRef is required")
+	}
+	queryString, err := buildSelectorQuery(map[string]interface{}{
+		"docType":    "evidence",
+		"admissions": map[string]interface{}{"$elemMatch": map[string]interface{}{"proceedingRef": proceedingRef}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return getQueryResultForQueryString(ctx, queryString)
+}
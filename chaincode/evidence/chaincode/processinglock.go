@@ -0,0 +1,88 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// requireNotLockedForProcessing rejects a mutation while e carries an
+// outstanding ProcessingLock. Checked by TransferCustody (and the other
+// transfers built on buildTransferCustody), TransferAndArchive,
+// InvalidateEvidence, ArchiveToCold, CheckOutEvidence, UpdateMetadata and
+// LockForProcessing; other mutators (e.g. AddAnnotation, AddTag,
+// ReactivateFromCold) do not check it.
+func requireNotLockedForProcessing(e *Evidence) error {
+	if e.ProcessingLock == nil {
+		return nil
+	}
+	return fmt.Errorf("evidence %s is locked for processing (ref %s, held by %s); it must be unlocked first", e.EvidenceID, e.ProcessingLock.ProcessingRef, e.ProcessingLock.HolderID)
+}
+
+// LockForProcessing prevents concurrent modification of evidenceID while
+// it's sent off for multi-step external processing (e.g. at an outside
+// lab), distinct from CheckOutEvidence's physical custody tracking. While
+// locked, the mutators listed on requireNotLockedForProcessing are rejected
+// for every caller, the lock holder included, until UnlockFromProcessing is
+// called or ExpireStaleHolds releases the lock; other mutators still apply.
+func (s *SmartContract) LockForProcessing(ctx contractapi.TransactionContextInterface, caseID, evidenceID, processingRef string) error {
+	if processingRef == "" {
+		return fmt.Errorf("processingRef is required")
+	}
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if err := requireNotLockedForProcessing(e); err != nil {
+		return err
+	}
+
+	holder, err := callerID(ctx)
+	if err != nil {
+		return err
+	}
+	event, err := newCustodyEvent(ctx, EventLock, holder, "", "", fmt.Sprintf("locked for processing: %s", processingRef))
+	if err != nil {
+		return err
+	}
+
+	e.ProcessingLock = &ProcessingLock{HolderID: holder, ProcessingRef: processingRef, LockedAt: event.Timestamp}
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return putEvidence(ctx, e)
+}
+
+// UnlockFromProcessing releases a ProcessingLock set by LockForProcessing,
+// recording resultSummary on the custody trail. Only the locking identity
+// or a supervisor may call this.
+func (s *SmartContract) UnlockFromProcessing(ctx contractapi.TransactionContextInterface, caseID, evidenceID, resultSummary string) error {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if e.ProcessingLock == nil {
+		return fmt.Errorf("evidence %s is not locked for processing", evidenceID)
+	}
+
+	caller, err := callerID(ctx)
+	if err != nil {
+		return err
+	}
+	if caller != e.ProcessingLock.HolderID {
+		if err := requireSupervisor(ctx); err != nil {
+			return fmt.Errorf("only the locking identity (%s) or a supervisor may unlock evidence %s", e.ProcessingLock.HolderID, evidenceID)
+		}
+	}
+
+	event, err := newCustodyEvent(ctx, EventUnlock, caller, "", "", resultSummary)
+	if err != nil {
+		return err
+	}
+
+	e.ProcessingLock = nil
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return putEvidence(ctx, e)
+}
@@ -0,0 +1,313 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// sortEvidences orders results by EvidenceID then CreatedAt, so clients see
+// a stable order across calls regardless of CouchDB's iteration order.
+func sortEvidences(evidences []*Evidence) {
+	sort.Slice(evidences, func(i, j int) bool {
+		if evidences[i].EvidenceID != evidences[j].EvidenceID {
+			return evidences[i].EvidenceID < evidences[j].EvidenceID
+		}
+		return evidences[i].CreatedAt < evidences[j].CreatedAt
+	})
+}
+
+// buildSelectorQuery marshals fields into a CouchDB selector query string of
+// the form {"selector":{...}}. Values are JSON-encoded rather than
+// interpolated into a hand-built string, so a caller-supplied value
+// containing a quote can't break out of its field and inject additional
+// selector clauses.
+func buildSelectorQuery(fields map[string]interface{}) (string, error) {
+	queryBytes, err := json.Marshal(map[string]interface{}{"selector": fields})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal query selector: %v", err)
+	}
+	return string(queryBytes), nil
+}
+
+// getQueryResultForQueryString executes a CouchDB rich query and unmarshals
+// every matching value into an Evidence record. Rich queries require a
+// CouchDB state database; on a peer configured via SetStateDBType as
+// LevelDB, it falls back to a range scan with in-memory selector matching
+// instead of calling GetQueryResult, which LevelDB doesn't support.
+func getQueryResultForQueryString(ctx contractapi.TransactionContextInterface, queryString string) ([]*Evidence, error) {
+	dbType, err := getStateDBType(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if dbType == "leveldb" {
+		var parsed struct {
+			Selector map[string]interface{} `json:"selector"`
+		}
+		if err := json.Unmarshal([]byte(queryString), &parsed); err != nil {
+			return nil, fmt.Errorf("failed to parse query selector for LevelDB fallback: %v", err)
+		}
+		return scanAllEvidenceWithSelector(ctx, parsed.Selector)
+	}
+
+	iterator, err := ctx.GetStub().GetQueryResult(queryString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute rich query: %v", err)
+	}
+	defer iterator.Close()
+
+	var results []*Evidence
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		var e Evidence
+		if err := json.Unmarshal(item.Value, &e); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal evidence from query result: %v", err)
+		}
+		results = append(results, &e)
+	}
+	sortEvidences(results)
+	return results, nil
+}
+
+// QueryEvidencesByCase returns every evidence record belonging to a case.
+func (s *SmartContract) QueryEvidencesByCase(ctx contractapi.TransactionContextInterface, caseID string) ([]*Evidence, error) {
+	if err := validateID("caseID", caseID); err != nil {
+		return nil, err
+	}
+	queryString, err := buildSelectorQuery(map[string]interface{}{"docType": "evidence", "caseID": caseID})
+	if err != nil {
+		return nil, err
+	}
+	return getQueryResultForQueryString(ctx, queryString)
+}
+
+// evidenceSortFields are the sort values QueryEvidencesByCaseOrdered
+// accepts.
+var evidenceSortFields = map[string]bool{
+	"priority": true,
+}
+
+// QueryEvidencesByCaseOrdered is QueryEvidencesByCase with server-side
+// triage ordering: sortField "priority" orders by Classification tier
+// descending (CLASSIFIED, then SENSITIVE, then ROUTINE), then by CreatedAt
+// ascending within a tier, so the most sensitive and oldest-unaddressed
+// items surface first without client-side sorting.
+func (s *SmartContract) QueryEvidencesByCaseOrdered(ctx contractapi.TransactionContextInterface, caseID, sortField string) ([]*Evidence, error) {
+	if !evidenceSortFields[sortField] {
+		return nil, fmt.Errorf("sortField must be one of %v, got %q", sortFieldNames(), sortField)
+	}
+	evidences, err := s.QueryEvidencesByCase(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(evidences, func(i, j int) bool {
+		ri, rj := classificationRank[evidences[i].Classification], classificationRank[evidences[j].Classification]
+		if ri != rj {
+			return ri > rj
+		}
+		return evidences[i].CreatedAt < evidences[j].CreatedAt
+	})
+	return evidences, nil
+}
+
+func sortFieldNames() []string {
+	names := make([]string, 0, len(evidenceSortFields))
+	for name := range evidenceSortFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// QueryEvidencesByOwner returns every evidence record currently held by the
+// given custodian.
+func (s *SmartContract) QueryEvidencesByOwner(ctx contractapi.TransactionContextInterface, currentOwner string) ([]*Evidence, error) {
+	queryString, err := buildSelectorQuery(map[string]interface{}{"docType": "evidence", "currentOwner": currentOwner})
+	if err != nil {
+		return nil, err
+	}
+	return getQueryResultForQueryString(ctx, queryString)
+}
+
+// QueryEvidencesByStatus returns every evidence record in the given status.
+func (s *SmartContract) QueryEvidencesByStatus(ctx contractapi.TransactionContextInterface, status string) ([]*Evidence, error) {
+	queryString, err := buildSelectorQuery(map[string]interface{}{"docType": "evidence", "status": status})
+	if err != nil {
+		return nil, err
+	}
+	return getQueryResultForQueryString(ctx, queryString)
+}
+
+// QueryEvidencesByCases returns every evidence record belonging to any of
+// the given case IDs in a single CouchDB query, so task forces working
+// multiple related cases avoid N separate round trips.
+func (s *SmartContract) QueryEvidencesByCases(ctx contractapi.TransactionContextInterface, caseIDsJSON string) ([]*Evidence, error) {
+	var caseIDs []string
+	if err := json.Unmarshal([]byte(caseIDsJSON), &caseIDs); err != nil {
+		return nil, fmt.Errorf("invalid caseIDs JSON: %v", err)
+	}
+	if len(caseIDs) == 0 {
+		return nil, fmt.Errorf("caseIDs must not be empty")
+	}
+
+	seen := map[string]bool{}
+	var deduped []string
+	for _, id := range caseIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		deduped = append(deduped, id)
+	}
+
+	queryString, err := buildSelectorQuery(map[string]interface{}{
+		"docType": "evidence",
+		"caseID":  map[string]interface{}{"$in": deduped},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return getQueryResultForQueryString(ctx, queryString)
+}
+
+// EvidenceFilter is the typed filter accepted by QueryEvidences. Any subset
+// of fields may be set; at least one is required.
+type EvidenceFilter struct {
+	Owner  string `json:"owner,omitempty"`
+	Status string `json:"status,omitempty"`
+	CaseID string `json:"caseID,omitempty"`
+	OrgMSP string `json:"orgMSP,omitempty"`
+}
+
+// QueryEvidences builds a single CouchDB selector combining any subset of
+// owner, status, caseID, and orgMSP, so common compound views like "my
+// active evidence" don't need client-side filtering on top of a narrower
+// query. Returns summaries sorted by EvidenceID then CreatedAt.
+func (s *SmartContract) QueryEvidences(ctx contractapi.TransactionContextInterface, filterJSON string) ([]*EvidenceSummary, error) {
+	var filter EvidenceFilter
+	if err := json.Unmarshal([]byte(filterJSON), &filter); err != nil {
+		return nil, fmt.Errorf("invalid filter JSON: %v", err)
+	}
+	if filter.Owner == "" && filter.Status == "" && filter.CaseID == "" && filter.OrgMSP == "" {
+		return nil, fmt.Errorf("filter must set at least one of owner, status, caseID, or orgMSP")
+	}
+
+	selector := map[string]interface{}{"docType": "evidence"}
+	if filter.Owner != "" {
+		selector["currentOwner"] = filter.Owner
+	}
+	if filter.Status != "" {
+		selector["status"] = filter.Status
+	}
+	if filter.CaseID != "" {
+		selector["caseID"] = filter.CaseID
+	}
+	if filter.OrgMSP != "" {
+		selector["orgMSP"] = filter.OrgMSP
+	}
+
+	queryString, err := buildSelectorQuery(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	evidences, err := getQueryResultForQueryString(ctx, queryString)
+	if err != nil {
+		return nil, err
+	}
+	summaries := make([]*EvidenceSummary, 0, len(evidences))
+	for _, e := range evidences {
+		summaries = append(summaries, toSummary(e))
+	}
+	return summaries, nil
+}
+
+// QueryEvidencesByCreationRange returns every evidence record across all
+// cases created within [startTime, endTime], sorted by creation time, for
+// monthly intake reporting that would otherwise require exporting everything
+// and filtering by hand.
+func (s *SmartContract) QueryEvidencesByCreationRange(ctx contractapi.TransactionContextInterface, startTime, endTime string) ([]*EvidenceSummary, error) {
+	startTime, err := normalizeTimestamp(startTime)
+	if err != nil {
+		return nil, err
+	}
+	endTime, err = normalizeTimestamp(endTime)
+	if err != nil {
+		return nil, err
+	}
+	if startTime > endTime {
+		return nil, fmt.Errorf("startTime %s is after endTime %s", startTime, endTime)
+	}
+
+	queryString, err := buildSelectorQuery(map[string]interface{}{
+		"docType":   "evidence",
+		"createdAt": map[string]interface{}{"$gte": startTime, "$lte": endTime},
+	})
+	if err != nil {
+		return nil, err
+	}
+	evidences, err := getQueryResultForQueryString(ctx, queryString)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(evidences, func(i, j int) bool {
+		return evidences[i].CreatedAt < evidences[j].CreatedAt
+	})
+	summaries := make([]*EvidenceSummary, 0, len(evidences))
+	for _, e := range evidences {
+		summaries = append(summaries, toSummary(e))
+	}
+	return summaries, nil
+}
+
+// QueryFailedVerifications returns every evidence item in a case whose most
+// recent VERIFY event failed, for the tamper-response queue to prioritize.
+// Items with no VERIFY event yet are not included: they haven't failed a
+// check, they just haven't had one.
+func (s *SmartContract) QueryFailedVerifications(ctx contractapi.TransactionContextInterface, caseID string) ([]*EvidenceSummary, error) {
+	evidences, err := s.QueryEvidencesByCase(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	var failed []*EvidenceSummary
+	for _, e := range evidences {
+		for i := len(e.Events) - 1; i >= 0; i-- {
+			if e.Events[i].EventType != EventVerify {
+				continue
+			}
+			if !e.Events[i].VerifyPassed {
+				failed = append(failed, toSummary(e))
+			}
+			break
+		}
+	}
+	return failed, nil
+}
+
+// QueryUntransferredEvidence returns evidence still held by its original
+// collector: items with a TransferCount of zero.
+func (s *SmartContract) QueryUntransferredEvidence(ctx contractapi.TransactionContextInterface, caseID string) ([]*EvidenceSummary, error) {
+	if err := validateID("caseID", caseID); err != nil {
+		return nil, err
+	}
+	queryString, err := buildSelectorQuery(map[string]interface{}{"docType": "evidence", "caseID": caseID, "transferCount": 0})
+	if err != nil {
+		return nil, err
+	}
+	evidences, err := getQueryResultForQueryString(ctx, queryString)
+	if err != nil {
+		return nil, err
+	}
+	summaries := make([]*EvidenceSummary, 0, len(evidences))
+	for _, e := range evidences {
+		summaries = append(summaries, toSummary(e))
+	}
+	return summaries, nil
+}
@@ -0,0 +1,93 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// reactivationApprovalPolicyKey stores whether ReactivateFromCold requires a
+// distinct supervisor to approve before the evidence actually comes back to
+// StatusActive.
+const reactivationApprovalPolicyKey = "POLICY_REACTIVATION_REQUIRES_APPROVAL"
+
+// SetReactivationRequiresApproval toggles whether bringing archived evidence
+// back to ACTIVE requires a separate ApproveReactivation call by a
+// supervisor other than whoever requested it.
+func (s *SmartContract) SetReactivationRequiresApproval(ctx contractapi.TransactionContextInterface, required bool) error {
+	value := "false"
+	if required {
+		value = "true"
+	}
+	return ctx.GetStub().PutState(reactivationApprovalPolicyKey, []byte(value))
+}
+
+func (s *SmartContract) reactivationRequiresApproval(ctx contractapi.TransactionContextInterface) (bool, error) {
+	bytes, err := ctx.GetStub().GetState(reactivationApprovalPolicyKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to read reactivation approval policy: %v", err)
+	}
+	return string(bytes) == "true", nil
+}
+
+// requestReactivation records a pending reactivation request on e, leaving
+// Status at StatusArchived until a distinct supervisor calls
+// ApproveReactivation. The request is visible to any GetEvidence caller via
+// PendingReactivation.
+func (s *SmartContract) requestReactivation(ctx contractapi.TransactionContextInterface, e *Evidence, reason string) error {
+	if e.PendingReactivation != nil {
+		return fmt.Errorf("evidence %s already has a pending reactivation request from %s", e.EvidenceID, e.PendingReactivation.RequestedBy)
+	}
+	requestedBy, err := callerID(ctx)
+	if err != nil {
+		return err
+	}
+
+	event, err := newCustodyEvent(ctx, EventReactivationRequest, e.CurrentOwner, "", "", reason)
+	if err != nil {
+		return err
+	}
+	e.PendingReactivation = &ReactivationRequest{
+		RequestedBy: requestedBy,
+		Reason:      reason,
+		RequestedAt: event.Timestamp,
+	}
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+	return putEvidence(ctx, e)
+}
+
+// ApproveReactivation completes a pending reactivation request, bringing
+// evidence back to StatusActive. Restricted to the supervisor role, and
+// rejects approval by the same identity that made the request.
+func (s *SmartContract) ApproveReactivation(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) error {
+	if err := requireSupervisor(ctx); err != nil {
+		return err
+	}
+
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if e.PendingReactivation == nil {
+		return fmt.Errorf("evidence %s has no pending reactivation request", evidenceID)
+	}
+
+	approver, err := callerID(ctx)
+	if err != nil {
+		return err
+	}
+	if approver == e.PendingReactivation.RequestedBy {
+		return fmt.Errorf("the supervisor who requested reactivation cannot also approve it")
+	}
+
+	event, err := newCustodyEvent(ctx, EventReactivationApproved, e.CurrentOwner, "", "", e.PendingReactivation.Reason)
+	if err != nil {
+		return err
+	}
+	e.Status = StatusActive
+	e.PendingReactivation = nil
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+	return putEvidence(ctx, e)
+}
@@ -0,0 +1,62 @@
+package chaincode
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// QueryReceipt lets a verifier later confirm the exact bytes served by a
+// query, in case a court challenges whether the data was altered after
+// retrieval.
+type QueryReceipt struct {
+	ChannelID   string `json:"channelID"`
+	TxTimestamp string `json:"txTimestamp"`
+	PayloadHash string `json:"payloadHash"`
+	Payload     string `json:"payload"`
+}
+
+// EvidenceWithReceipt pairs an evidence record with a signed-at-query-time
+// receipt.
+type EvidenceWithReceipt struct {
+	Evidence *Evidence     `json:"evidence"`
+	Receipt  *QueryReceipt `json:"receipt"`
+}
+
+// GetEvidenceWithReceipt returns an evidence record along with a chain-of-
+// custody signing receipt covering the exact serialized payload returned.
+// Exporting a receipt hands the caller a self-contained, citable copy of the
+// record, so it requires clearance matching the evidence's classification.
+func (s *SmartContract) GetEvidenceWithReceipt(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) (*EvidenceWithReceipt, error) {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireClearance(ctx, e.Classification); err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize evidence for receipt: %v", err)
+	}
+	hash := sha256.Sum256(payload)
+
+	ts, err := getTxTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EvidenceWithReceipt{
+		Evidence: e,
+		Receipt: &QueryReceipt{
+			ChannelID:   ctx.GetStub().GetChannelID(),
+			TxTimestamp: ts,
+			PayloadHash: hex.EncodeToString(hash[:]),
+			Payload:     string(payload),
+		},
+	}, nil
+}
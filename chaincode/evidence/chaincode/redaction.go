@@ -0,0 +1,108 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// redactedMetadataCollection is the private data collection the original
+// values of redacted metadata fields are moved into. Its membership policy
+// (who can actually read it, e.g. the court's org only) is configured
+// outside this chaincode, in the channel's collections config.
+const redactedMetadataCollection = "redactedMetadataCollection"
+
+// redactionMarker replaces a redacted field's value in the public Metadata
+// map, so readers can tell the field existed without seeing its content.
+const redactionMarker = "[REDACTED]"
+
+func redactionKey(caseID, evidenceID, fieldPath string) string {
+	return fmt.Sprintf("REDACTION_%s_%s_%s", caseID, evidenceID, fieldPath)
+}
+
+// RedactMetadataField hides a metadata field from public view under a court
+// order: its value is moved into the redactedMetadataCollection private
+// data collection (restricted to whichever orgs that collection's policy
+// grants read access to, typically the court) and replaced in the public
+// Metadata map with redactionMarker. The original value is never lost, only
+// relocated, and can be restored with UnredactMetadataField.
+func (s *SmartContract) RedactMetadataField(ctx contractapi.TransactionContextInterface, caseID, evidenceID, fieldPath, courtOrderRef string) error {
+	if err := requireSupervisor(ctx); err != nil {
+		return err
+	}
+	if courtOrderRef == "" {
+		return fmt.Errorf("courtOrderRef is required")
+	}
+
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	original, ok := e.Metadata[fieldPath]
+	if !ok {
+		return fmt.Errorf("evidence %s has no metadata field %q", evidenceID, fieldPath)
+	}
+	if original == redactionMarker {
+		return fmt.Errorf("metadata field %q is already redacted", fieldPath)
+	}
+
+	if err := ctx.GetStub().PutPrivateData(redactedMetadataCollection, redactionKey(caseID, evidenceID, fieldPath), []byte(original)); err != nil {
+		return fmt.Errorf("failed to store redacted value: %v", err)
+	}
+
+	e.Metadata[fieldPath] = redactionMarker
+
+	event, err := newCustodyEvent(ctx, EventRedact, e.CurrentOwner, "", "", fmt.Sprintf("court order %s: redacted field %q", courtOrderRef, fieldPath))
+	if err != nil {
+		return err
+	}
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return putEvidence(ctx, e)
+}
+
+// UnredactMetadataField restores a field previously hidden by
+// RedactMetadataField, reading its original value back out of the
+// redactedMetadataCollection private data collection. Supervisor-only, and
+// always requires a court order reference, whether or not it matches the
+// one the redaction was made under.
+func (s *SmartContract) UnredactMetadataField(ctx contractapi.TransactionContextInterface, caseID, evidenceID, fieldPath, courtOrderRef string) error {
+	if err := requireSupervisor(ctx); err != nil {
+		return err
+	}
+	if courtOrderRef == "" {
+		return fmt.Errorf("courtOrderRef is required")
+	}
+
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if e.Metadata[fieldPath] != redactionMarker {
+		return fmt.Errorf("metadata field %q on evidence %s is not currently redacted", fieldPath, evidenceID)
+	}
+
+	key := redactionKey(caseID, evidenceID, fieldPath)
+	original, err := ctx.GetStub().GetPrivateData(redactedMetadataCollection, key)
+	if err != nil {
+		return fmt.Errorf("failed to read redacted value: %v", err)
+	}
+	if original == nil {
+		return fmt.Errorf("no stored redaction found for field %q on evidence %s", fieldPath, evidenceID)
+	}
+
+	e.Metadata[fieldPath] = string(original)
+
+	event, err := newCustodyEvent(ctx, EventUnredact, e.CurrentOwner, "", "", fmt.Sprintf("court order %s: unredacted field %q", courtOrderRef, fieldPath))
+	if err != nil {
+		return err
+	}
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	if err := putEvidence(ctx, e); err != nil {
+		return err
+	}
+	return ctx.GetStub().DelPrivateData(redactedMetadataCollection, key)
+}
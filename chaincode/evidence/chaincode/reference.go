@@ -0,0 +1,52 @@
+package chaincode
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// EvidenceReference is a compact, deterministic token meant to be printed
+// as a QR code on a physical evidence bag, letting a mobile scanner
+// validate the physical-to-digital linkage without pulling the full
+// record. IntegrityCode is the first 8 hex characters of a SHA-256 hash
+// over the other fields, so a scanner can catch a mistyped or tampered
+// code before trusting it.
+type EvidenceReference struct {
+	ChannelID     string `json:"channelID"`
+	CaseID        string `json:"caseID"`
+	EvidenceID    string `json:"evidenceID"`
+	Hash          string `json:"hash"`
+	IntegrityCode string `json:"integrityCode"`
+}
+
+// GetEvidenceReference returns the compact reference token for evidenceID.
+// Because it's derived only from the channel ID, case ID, evidence ID, and
+// current hash, the token is stable for a given state and changes only if
+// the record's hash is later superseded (e.g. VerifyEvidenceIntegrity
+// recording a mismatch doesn't change Hash itself, so the token remains
+// valid until the record is genuinely re-hashed).
+func (s *SmartContract) GetEvidenceReference(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) (*EvidenceReference, error) {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	channelID := ctx.GetStub().GetChannelID()
+	integrityCode := evidenceReferenceIntegrityCode(channelID, caseID, evidenceID, e.Hash)
+
+	return &EvidenceReference{
+		ChannelID:     channelID,
+		CaseID:        caseID,
+		EvidenceID:    evidenceID,
+		Hash:          e.Hash,
+		IntegrityCode: integrityCode,
+	}, nil
+}
+
+func evidenceReferenceIntegrityCode(channelID, caseID, evidenceID, hash string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%s", channelID, caseID, evidenceID, hash)))
+	return hex.EncodeToString(sum[:])[:8]
+}
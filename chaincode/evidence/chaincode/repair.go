@@ -0,0 +1,67 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// RepairMissingTxIDs backfills TxID on custody events left blank by an
+// early bug, by replaying the key's own commit history (GetHistoryForKey)
+// and matching each historical snapshot's newly appended events to the
+// corresponding blank entries by event type and timestamp. Supervisor-only,
+// since it rewrites (filling in, never overwriting) committed event data.
+func (s *SmartContract) RepairMissingTxIDs(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) (int, error) {
+	if err := requireSupervisor(ctx); err != nil {
+		return 0, err
+	}
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return 0, err
+	}
+
+	key := evidenceKey(caseID, evidenceID)
+	iterator, err := ctx.GetStub().GetHistoryForKey(key)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read history for %s: %v", key, err)
+	}
+	defer iterator.Close()
+
+	repaired := 0
+	priorLen := 0
+	for iterator.HasNext() {
+		mod, err := iterator.Next()
+		if err != nil {
+			return repaired, err
+		}
+		if mod.IsDelete {
+			continue
+		}
+		var snapshot Evidence
+		if err := json.Unmarshal(mod.Value, &snapshot); err != nil {
+			return repaired, fmt.Errorf("failed to unmarshal history entry %s: %v", mod.TxId, err)
+		}
+		for i := priorLen; i < len(snapshot.Events) && i < len(e.Events); i++ {
+			if e.Events[i].TxID != "" {
+				continue
+			}
+			if e.Events[i].EventType != snapshot.Events[i].EventType || e.Events[i].Timestamp != snapshot.Events[i].Timestamp {
+				continue
+			}
+			e.Events[i].TxID = mod.TxId
+			repaired++
+		}
+		if len(snapshot.Events) > priorLen {
+			priorLen = len(snapshot.Events)
+		}
+	}
+
+	if repaired == 0 {
+		return 0, nil
+	}
+	if err := putEvidence(ctx, e); err != nil {
+		return 0, err
+	}
+	return repaired, nil
+}
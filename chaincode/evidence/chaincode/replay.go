@@ -0,0 +1,40 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// EvidenceSnapshot is the payload of the synthetic event emitted by
+// ReplayEvents, carrying the full current record for one evidence item.
+type EvidenceSnapshot struct {
+	CaseID   string    `json:"caseID"`
+	Evidence *Evidence `json:"evidence"`
+}
+
+// ReplayEvents re-emits a synthetic EvidenceSnapshot event per evidence item
+// in a case, so a freshly-subscribed off-chain indexer with no prior event
+// history can bootstrap its state from the current ledger contents.
+//
+// A Fabric transaction can only call SetEvent once, so this cannot emit one
+// event per item within a single invocation. Instead it emits exactly one
+// event per call: callers replaying a whole case must invoke ReplayEvents
+// once per evidenceID (e.g. driven off QueryEvidencesByCase), not once for
+// the case as a whole.
+func (s *SmartContract) ReplayEvents(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) error {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(&EvidenceSnapshot{CaseID: caseID, Evidence: e})
+	if err != nil {
+		return fmt.Errorf("failed to marshal EvidenceSnapshot payload: %v", err)
+	}
+	if err := ctx.GetStub().SetEvent("EvidenceSnapshot", payload); err != nil {
+		return fmt.Errorf("failed to emit EvidenceSnapshot event: %v", err)
+	}
+	return nil
+}
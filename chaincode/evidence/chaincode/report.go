@@ -0,0 +1,56 @@
+package chaincode
+
+import (
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// CaseReport aggregates dashboard-facing statistics across every evidence
+// item in a case, computed from a single QueryEvidencesByCase call.
+type CaseReport struct {
+	CaseID             string         `json:"caseID"`
+	TotalEvidence      int            `json:"totalEvidence"`
+	CountsByStatus     map[string]int `json:"countsByStatus"`
+	TotalCustodyEvents int            `json:"totalCustodyEvents"`
+	DistinctCustodians int            `json:"distinctCustodians"`
+	EarliestActivity   string         `json:"earliestActivity,omitempty"`
+	LatestActivity     string         `json:"latestActivity,omitempty"`
+	InvalidatedCount   int            `json:"invalidatedCount"`
+}
+
+// GetCaseReport returns a one-call aggregate report over every evidence item
+// in a case, replacing the handful of separate queries a dashboard would
+// otherwise need to issue.
+func (s *SmartContract) GetCaseReport(ctx contractapi.TransactionContextInterface, caseID string) (*CaseReport, error) {
+	evidences, err := s.QueryEvidencesByCase(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CaseReport{
+		CaseID:         caseID,
+		TotalEvidence:  len(evidences),
+		CountsByStatus: map[string]int{},
+	}
+
+	custodians := map[string]bool{}
+	for _, e := range evidences {
+		report.CountsByStatus[e.Status]++
+		report.TotalCustodyEvents += len(e.Events)
+		custodians[e.CurrentOwner] = true
+		if e.Status == StatusInvalidated {
+			report.InvalidatedCount++
+		}
+
+		for _, event := range e.Events {
+			if report.EarliestActivity == "" || event.Timestamp < report.EarliestActivity {
+				report.EarliestActivity = event.Timestamp
+			}
+			if report.LatestActivity == "" || event.Timestamp > report.LatestActivity {
+				report.LatestActivity = event.Timestamp
+			}
+		}
+	}
+	report.DistinctCustodians = len(custodians)
+
+	return report, nil
+}
@@ -0,0 +1,173 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// reservationKeyPrefix namespaces short-lived evidence ID claims, kept
+// separate from evidenceKeyPrefix so a reservation never collides with the
+// eventual evidence record it guards.
+const reservationKeyPrefix = "RESERVATION_"
+
+// reservationTTLSeconds is how long a reservation holds an evidence ID
+// before it's considered abandoned and up for grabs again.
+const reservationTTLSeconds = 300
+
+func reservationKey(caseID, evidenceID string) string {
+	return fmt.Sprintf("%s%s_%s", reservationKeyPrefix, caseID, evidenceID)
+}
+
+// reservation is the short-lived claim on an evidence ID written by
+// ReserveEvidenceID, so two clients racing to create the same ID get a
+// clean "already claimed" response instead of a commit-time MVCC conflict.
+type reservation struct {
+	DocType    string `json:"docType"`
+	CaseID     string `json:"caseID"`
+	EvidenceID string `json:"evidenceID"`
+	ReservedBy string `json:"reservedBy"`
+	ExpiresAt  string `json:"expiresAt"`
+}
+
+func getReservation(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) (*reservation, error) {
+	bytes, err := ctx.GetStub().GetState(reservationKey(caseID, evidenceID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reservation: %v", err)
+	}
+	if bytes == nil {
+		return nil, nil
+	}
+	var r reservation
+	if err := json.Unmarshal(bytes, &r); err != nil {
+		return nil, fmt.Errorf("corrupt reservation record: %v", err)
+	}
+	return &r, nil
+}
+
+// ReserveEvidenceID claims an evidence ID for the calling identity for
+// reservationTTLSeconds, so a UI can surface "this ID is being claimed"
+// immediately instead of the create failing at commit time. Fails if the
+// ID already has a live, unexpired reservation held by someone else, or if
+// evidence already exists under it.
+func (s *SmartContract) ReserveEvidenceID(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) error {
+	if err := validateID("caseID", caseID); err != nil {
+		return err
+	}
+	if err := validateID("evidenceID", evidenceID); err != nil {
+		return err
+	}
+	exists, err := s.EvidenceExists(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("evidence %s already exists in case %s", evidenceID, caseID)
+	}
+
+	caller, err := callerID(ctx)
+	if err != nil {
+		return err
+	}
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	existing, err := getReservation(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ReservedBy != caller && existing.ExpiresAt > now {
+		return fmt.Errorf("evidence ID %s is already reserved until %s", evidenceID, existing.ExpiresAt)
+	}
+
+	expiresAt, err := reservationExpiry(ctx)
+	if err != nil {
+		return err
+	}
+	r := &reservation{
+		DocType:    "reservation",
+		CaseID:     caseID,
+		EvidenceID: evidenceID,
+		ReservedBy: caller,
+		ExpiresAt:  expiresAt,
+	}
+	bytes, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to marshal reservation: %v", err)
+	}
+	return ctx.GetStub().PutState(reservationKey(caseID, evidenceID), bytes)
+}
+
+// ReleaseReservation drops a reservation early, e.g. when the claiming
+// client abandons the create. Only the reserving identity may release it.
+func (s *SmartContract) ReleaseReservation(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) error {
+	r, err := getReservation(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return nil
+	}
+	caller, err := callerID(ctx)
+	if err != nil {
+		return err
+	}
+	if r.ReservedBy != caller {
+		return fmt.Errorf("only the reserving identity may release this reservation")
+	}
+	return ctx.GetStub().DelState(reservationKey(caseID, evidenceID))
+}
+
+// checkReservation lets CreateEvidence through when there's no reservation,
+// the reservation has expired, or the caller holds it; otherwise it rejects
+// so only the reserving identity can complete the create within the TTL.
+func checkReservation(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) error {
+	r, err := getReservation(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return nil
+	}
+	now, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+	if r.ExpiresAt <= now {
+		return nil
+	}
+	caller, err := callerID(ctx)
+	if err != nil {
+		return err
+	}
+	if r.ReservedBy != caller {
+		return fmt.Errorf("evidence ID %s is reserved by another identity until %s", evidenceID, r.ExpiresAt)
+	}
+	return nil
+}
+
+// clearReservation removes a satisfied reservation after a successful
+// create, so it doesn't linger as dead state until its TTL lapses.
+func clearReservation(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) error {
+	r, err := getReservation(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return nil
+	}
+	return ctx.GetStub().DelState(reservationKey(caseID, evidenceID))
+}
+
+func reservationExpiry(ctx contractapi.TransactionContextInterface) (string, error) {
+	ts, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return "", fmt.Errorf("failed to read transaction timestamp: %v", err)
+	}
+	expires := time.Unix(ts.Seconds, int64(ts.Nanos)).UTC().Add(reservationTTLSeconds * time.Second)
+	return expires.Format(time.RFC3339Nano), nil
+}
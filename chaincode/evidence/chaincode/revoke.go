@@ -0,0 +1,110 @@
+package chaincode
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// transferRevokeWindowKey stores the configured grace period (in seconds)
+// during which a fat-fingered transfer can be revoked. Defaults to
+// defaultTransferRevokeWindowSeconds when unset.
+const transferRevokeWindowKey = "POLICY_TRANSFER_REVOKE_WINDOW_SECONDS"
+
+const defaultTransferRevokeWindowSeconds = 15 * 60
+
+// SetTransferRevokeWindow configures how long, in seconds after a transfer,
+// RevokeLastTransfer will accept reverting it.
+func (s *SmartContract) SetTransferRevokeWindow(ctx contractapi.TransactionContextInterface, seconds int) error {
+	if seconds < 0 {
+		return fmt.Errorf("seconds must be non-negative")
+	}
+	return ctx.GetStub().PutState(transferRevokeWindowKey, []byte(fmt.Sprintf("%d", seconds)))
+}
+
+func (s *SmartContract) getTransferRevokeWindow(ctx contractapi.TransactionContextInterface) (int, error) {
+	bytes, err := ctx.GetStub().GetState(transferRevokeWindowKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read transfer revoke window: %v", err)
+	}
+	if bytes == nil {
+		return defaultTransferRevokeWindowSeconds, nil
+	}
+	var seconds int
+	if _, err := fmt.Sscanf(string(bytes), "%d", &seconds); err != nil {
+		return 0, fmt.Errorf("corrupt transfer revoke window value: %v", err)
+	}
+	return seconds, nil
+}
+
+// requireCurrentOwnerCaller rejects the call unless it comes from the org
+// registered for evidence's current owner. If that custodian isn't
+// registered, the check is skipped, consistent with how
+// requiresRegisteredCustodian treats an unconfigured registry elsewhere.
+func (s *SmartContract) requireCurrentOwnerCaller(ctx contractapi.TransactionContextInterface, e *Evidence) error {
+	custodian, err := s.GetCustodian(ctx, e.CurrentOwner)
+	if err != nil {
+		return nil
+	}
+	callerMSP, err := getClientOrgMSP(ctx)
+	if err != nil {
+		return err
+	}
+	if callerMSP != custodian.OrgMSP {
+		return fmt.Errorf("only the current owner's org (%s) may revoke this transfer", custodian.OrgMSP)
+	}
+	return nil
+}
+
+// RevokeLastTransfer reverts a fat-fingered transfer: if the last custody
+// event is a TRANSFER recorded within the configured grace window, it
+// restores CurrentOwner to the prior holder and appends a
+// TRANSFER_REVOKED event rather than leaving a confusing corrective
+// round-trip in the log. Only the current owner's org may call it, and
+// only within the window.
+func (s *SmartContract) RevokeLastTransfer(ctx contractapi.TransactionContextInterface, caseID, evidenceID, reason string) error {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if len(e.Events) == 0 || e.Events[len(e.Events)-1].EventType != EventTransfer {
+		return fmt.Errorf("the last custody event for evidence %s is not a transfer", evidenceID)
+	}
+	last := e.Events[len(e.Events)-1]
+
+	windowSeconds, err := s.getTransferRevokeWindow(ctx)
+	if err != nil {
+		return err
+	}
+	transferredAt, err := time.Parse(time.RFC3339Nano, last.Timestamp)
+	if err != nil {
+		return fmt.Errorf("failed to parse transfer timestamp: %v", err)
+	}
+	ts, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return fmt.Errorf("failed to read transaction timestamp: %v", err)
+	}
+	now := time.Unix(ts.Seconds, int64(ts.Nanos)).UTC()
+	if now.After(transferredAt.Add(time.Duration(windowSeconds) * time.Second)) {
+		return fmt.Errorf("transfer of evidence %s was recorded at %s, outside the %ds revoke window", evidenceID, last.Timestamp, windowSeconds)
+	}
+
+	if err := s.requireCurrentOwnerCaller(ctx, e); err != nil {
+		return err
+	}
+
+	previousOwner := last.FromOwner
+	event, err := newCustodyEvent(ctx, EventTransferRevoked, e.CurrentOwner, e.CurrentOwner, previousOwner, reason)
+	if err != nil {
+		return err
+	}
+	e.CurrentOwner = previousOwner
+	if e.TransferCount > 0 {
+		e.TransferCount--
+	}
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return putEvidence(ctx, e)
+}
@@ -0,0 +1,115 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// sequencingKeyPrefix namespaces per-case sequential-numbering policy, for
+// agencies that require evidence IDs within a case to run EX-001, EX-002,
+// ... with no gaps or duplicates.
+const sequencingKeyPrefix = "CASE_SEQUENCING_"
+
+// sequencingNumberWidth is the zero-padded digit width used when formatting
+// the expected next evidence ID, e.g. width 3 gives "EX-001".
+const sequencingNumberWidth = 3
+
+func sequencingKey(caseID string) string {
+	return sequencingKeyPrefix + caseID
+}
+
+// sequencingPolicy is the per-case sequential-numbering configuration
+// written by SetSequentialNumbering.
+type sequencingPolicy struct {
+	Enabled bool   `json:"enabled"`
+	Prefix  string `json:"prefix"`
+	Next    int    `json:"next"`
+}
+
+func getSequencingPolicy(ctx contractapi.TransactionContextInterface, caseID string) (*sequencingPolicy, error) {
+	bytes, err := ctx.GetStub().GetState(sequencingKey(caseID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sequencing policy for case %s: %v", caseID, err)
+	}
+	if bytes == nil {
+		return nil, nil
+	}
+	var p sequencingPolicy
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return nil, fmt.Errorf("corrupt sequencing policy for case %s: %v", caseID, err)
+	}
+	return &p, nil
+}
+
+func putSequencingPolicy(ctx contractapi.TransactionContextInterface, caseID string, p *sequencingPolicy) error {
+	bytes, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sequencing policy: %v", err)
+	}
+	return ctx.GetStub().PutState(sequencingKey(caseID), bytes)
+}
+
+func formatSequentialID(prefix string, n int) string {
+	return fmt.Sprintf("%s-%0*d", prefix, sequencingNumberWidth, n)
+}
+
+// SetSequentialNumbering enables or disables strict sequential evidence
+// numbering for caseID. While enabled, CreateEvidence rejects any
+// evidenceID other than the expected "<prefix>-<next number>" (e.g.
+// "EX-001"), closing gaps and duplicates. Disabling preserves the next
+// counter, so re-enabling later resumes where it left off.
+func (s *SmartContract) SetSequentialNumbering(ctx contractapi.TransactionContextInterface, caseID, prefix string, enabled bool) error {
+	if err := validateID("caseID", caseID); err != nil {
+		return err
+	}
+	if enabled && prefix == "" {
+		return fmt.Errorf("prefix is required to enable sequential numbering")
+	}
+	p, err := getSequencingPolicy(ctx, caseID)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		p = &sequencingPolicy{Next: 1}
+	}
+	p.Enabled = enabled
+	if prefix != "" {
+		p.Prefix = prefix
+	}
+	return putSequencingPolicy(ctx, caseID, p)
+}
+
+// GetNextEvidenceNumber returns the evidence ID CreateEvidence will expect
+// next for caseID, or an error if sequential numbering isn't enabled there.
+func (s *SmartContract) GetNextEvidenceNumber(ctx contractapi.TransactionContextInterface, caseID string) (string, error) {
+	p, err := getSequencingPolicy(ctx, caseID)
+	if err != nil {
+		return "", err
+	}
+	if p == nil || !p.Enabled {
+		return "", fmt.Errorf("case %s does not have sequential numbering enabled", caseID)
+	}
+	return formatSequentialID(p.Prefix, p.Next), nil
+}
+
+// checkSequentialNumbering rejects evidenceID if caseID has sequential
+// numbering enabled and evidenceID isn't the expected next number, then
+// advances the counter. Called from CreateEvidence just before the record
+// is written, so a rejected create leaves the counter untouched.
+func checkSequentialNumbering(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) error {
+	p, err := getSequencingPolicy(ctx, caseID)
+	if err != nil {
+		return err
+	}
+	if p == nil || !p.Enabled {
+		return nil
+	}
+	expected := formatSequentialID(p.Prefix, p.Next)
+	if evidenceID != expected {
+		return fmt.Errorf("case %s requires sequential evidence numbering; expected %s, got %s", caseID, expected, evidenceID)
+	}
+	p.Next++
+	return putSequencingPolicy(ctx, caseID, p)
+}
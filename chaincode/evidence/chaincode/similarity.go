@@ -0,0 +1,56 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// hammingDistance returns the number of differing characters between two
+// equal-length perceptual hash strings. Hashes of differing length are
+// considered maximally distant.
+func hammingDistance(a, b string) int {
+	if len(a) != len(b) {
+		max := len(a)
+		if len(b) > max {
+			max = len(b)
+		}
+		return max
+	}
+	distance := 0
+	for i := range a {
+		if a[i] != b[i] {
+			distance++
+		}
+	}
+	return distance
+}
+
+// FindSimilarEvidence returns evidence in the same case whose client-supplied
+// perceptual hash is within maxHammingDistance of the target's, to flag
+// visually similar images/videos beyond exact SHA-256 matches.
+func (s *SmartContract) FindSimilarEvidence(ctx contractapi.TransactionContextInterface, caseID, evidenceID string, maxHammingDistance int) ([]*EvidenceSummary, error) {
+	target, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return nil, err
+	}
+	if target.PerceptualHash == "" {
+		return nil, fmt.Errorf("evidence %s has no perceptual hash recorded", evidenceID)
+	}
+
+	candidates, err := s.QueryEvidencesByCase(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	var similar []*EvidenceSummary
+	for _, c := range candidates {
+		if c.EvidenceID == evidenceID || c.PerceptualHash == "" {
+			continue
+		}
+		if hammingDistance(target.PerceptualHash, c.PerceptualHash) <= maxHammingDistance {
+			similar = append(similar, toSummary(c))
+		}
+	}
+	return similar, nil
+}
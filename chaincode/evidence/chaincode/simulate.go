@@ -0,0 +1,34 @@
+package chaincode
+
+import "github.com/hyperledger/fabric-contract-api-go/contractapi"
+
+// SimulationResult previews the evidence record and custody event an
+// operation would produce, without writing anything: no PutState, no
+// SetEvent. It lets a UI show an accurate confirmation (including the exact
+// validation errors a real submission would hit) before the user commits.
+type SimulationResult struct {
+	Evidence *Evidence    `json:"evidence"`
+	Event    CustodyEvent `json:"event"`
+}
+
+// SimulateTransfer runs every check TransferCustody would run and returns
+// the resulting evidence record and TRANSFER event, without persisting
+// anything.
+func (s *SmartContract) SimulateTransfer(ctx contractapi.TransactionContextInterface, caseID, evidenceID, newCustodian, reason, examinerID, credentialRef, witnessID string) (*SimulationResult, error) {
+	e, event, err := s.buildTransferCustody(ctx, caseID, evidenceID, newCustodian, reason, nil, examinerID, credentialRef, witnessID, false)
+	if err != nil {
+		return nil, err
+	}
+	return &SimulationResult{Evidence: e, Event: event}, nil
+}
+
+// SimulateInvalidate runs every check InvalidateEvidence would run and
+// returns the resulting evidence record and INVALIDATE event, without
+// persisting anything.
+func (s *SmartContract) SimulateInvalidate(ctx contractapi.TransactionContextInterface, caseID, evidenceID, reasonCode, detail string) (*SimulationResult, error) {
+	e, event, err := s.buildInvalidateEvidence(ctx, caseID, evidenceID, reasonCode, detail)
+	if err != nil {
+		return nil, err
+	}
+	return &SimulationResult{Evidence: e, Event: event}, nil
+}
@@ -0,0 +1,44 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// EstimateEvidenceSize returns the approximate number of bytes evidenceID's
+// stored record occupies, measured as its marshaled JSON size. This is what
+// actually gets written to the world state, so it's a reasonable proxy for
+// planning storage and spotting pathologically large records (e.g. huge
+// event histories) that need CompactEventHistory.
+func (s *SmartContract) EstimateEvidenceSize(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) (int, error) {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return 0, err
+	}
+	bytes, err := json.Marshal(e)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal evidence %s: %v", evidenceID, err)
+	}
+	return len(bytes), nil
+}
+
+// EstimateCaseSize sums EstimateEvidenceSize across every evidence record in
+// a case, for sizing up a bulk operation (batch create, compaction) before
+// running it.
+func (s *SmartContract) EstimateCaseSize(ctx contractapi.TransactionContextInterface, caseID string) (int, error) {
+	evidences, err := s.QueryEvidencesByCase(ctx, caseID)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, e := range evidences {
+		bytes, err := json.Marshal(e)
+		if err != nil {
+			return total, fmt.Errorf("failed to marshal evidence %s: %v", e.EvidenceID, err)
+		}
+		total += len(bytes)
+	}
+	return total, nil
+}
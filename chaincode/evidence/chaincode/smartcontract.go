@@ -0,0 +1,717 @@
+// Package chaincode implements the evidence custody smart contract backing
+// the JumpServer blockchain app's hot/cold chains.
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// SmartContract implements the evidence custody chaincode.
+type SmartContract struct {
+	contractapi.Contract
+}
+
+// EvidenceExists reports whether an evidence record exists for the given
+// case and evidence ID. It shares the same lookup path as getEvidence so
+// "not found" is determined identically everywhere in the contract.
+func (s *SmartContract) EvidenceExists(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) (bool, error) {
+	_, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateEvidence registers a new piece of evidence under a case, recording
+// the initial CREATE custody event.
+func (s *SmartContract) CreateEvidence(ctx contractapi.TransactionContextInterface, caseID, evidenceID, hash, cid, metadataJSON, custodianID, contentType, perceptualHash, hashAlgorithm, classification, examinerID, credentialRef, evidenceKind, originalEvidenceID, timestampToken, acquisitionTool, acquisitionToolVersion string) error {
+	var errs ValidationErrors
+
+	if err := validateID("custodianID", custodianID); err != nil {
+		errs = append(errs, &ValidationError{Field: "custodianID", Message: err.Error()})
+	}
+	normalizedAlgorithm, err := validateHashAlgorithm(hashAlgorithm)
+	if err != nil {
+		errs = append(errs, &ValidationError{Field: "hashAlgorithm", Message: err.Error()})
+	} else if err := validateHashFormat(normalizedAlgorithm, hash); err != nil {
+		errs = append(errs, &ValidationError{Field: "hash", Message: err.Error()})
+	}
+	normalizedClassification, err := validateClassification(classification)
+	if err != nil {
+		errs = append(errs, &ValidationError{Field: "classification", Message: err.Error()})
+	}
+	normalizedKind, err := validateEvidenceKind(evidenceKind, originalEvidenceID)
+	if err != nil {
+		errs = append(errs, &ValidationError{Field: "evidenceKind", Message: err.Error()})
+	}
+	if err := s.validateExaminer(ctx, examinerID, credentialRef); err != nil {
+		errs = append(errs, &ValidationError{Field: "examinerID", Message: err.Error()})
+	}
+	if err := s.validateContentType(ctx, contentType); err != nil {
+		errs = append(errs, &ValidationError{Field: "contentType", Message: err.Error()})
+	}
+	if timestampToken != "" {
+		if err := validateTimestampTokenFormat(timestampToken); err != nil {
+			errs = append(errs, &ValidationError{Field: "timestampToken", Message: err.Error()})
+		}
+	}
+	if err := s.validateAcquisitionTool(ctx, acquisitionTool, acquisitionToolVersion); err != nil {
+		errs = append(errs, &ValidationError{Field: "acquisitionTool", Message: err.Error()})
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	hashAlgorithm = normalizedAlgorithm
+	classification = normalizedClassification
+	evidenceKind = normalizedKind
+
+	if err := s.checkOriginalForCopy(ctx, caseID, evidenceKind, originalEvidenceID); err != nil {
+		return err
+	}
+
+	exists, err := s.EvidenceExists(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("evidence %s already exists in case %s", evidenceID, caseID)
+	}
+	if err := checkSequentialNumbering(ctx, caseID, evidenceID); err != nil {
+		return err
+	}
+	if err := checkReservation(ctx, caseID, evidenceID); err != nil {
+		return err
+	}
+
+	metadata := map[string]string{}
+	if metadataJSON != "" {
+		if err := json.Unmarshal([]byte(metadataJSON), &metadata); err != nil {
+			return fmt.Errorf("invalid metadata JSON: %v", err)
+		}
+	}
+
+	ts, err := getTxTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+	orgMSP, err := getClientOrgMSP(ctx)
+	if err != nil {
+		return err
+	}
+
+	backdateReason := ""
+	c, err := getOrCreateCase(ctx, caseID)
+	if err != nil {
+		return err
+	}
+	if c.OpenedAt != "" && ts < c.OpenedAt {
+		policy, err := backdatePolicy(ctx)
+		if err != nil {
+			return err
+		}
+		switch policy {
+		case "reject":
+			return fmt.Errorf("evidence %s is dated %s, before case %s was opened (%s)", evidenceID, ts, caseID, c.OpenedAt)
+		default:
+			backdateReason = fmt.Sprintf("warning: dated %s, before case %s was opened (%s)", ts, caseID, c.OpenedAt)
+		}
+	}
+
+	event, err := newCustodyEvent(ctx, EventCreate, custodianID, "", custodianID, backdateReason)
+	if err != nil {
+		return err
+	}
+	event.ExaminerID = examinerID
+	event.CredentialRef = credentialRef
+
+	evidence := &Evidence{
+		DocType:                "evidence",
+		CaseID:                 caseID,
+		EvidenceID:             evidenceID,
+		Hash:                   hash,
+		CID:                    cid,
+		CIDs:                   []string{cid},
+		Metadata:               metadata,
+		Status:                 StatusActive,
+		CurrentOwner:           custodianID,
+		OrgMSP:                 orgMSP,
+		CreatedBy:              custodianID,
+		CreatedAt:              ts,
+		UpdatedAt:              ts,
+		SchemaVersion:          CurrentSchemaVersion,
+		ContentType:            contentType,
+		PerceptualHash:         perceptualHash,
+		HashAlgorithm:          hashAlgorithm,
+		Classification:         classification,
+		Events:                 []CustodyEvent{event},
+		EvidenceKind:           evidenceKind,
+		OriginalEvidenceID:     originalEvidenceID,
+		TimestampToken:         timestampToken,
+		AcquisitionTool:        acquisitionTool,
+		AcquisitionToolVersion: acquisitionToolVersion,
+	}
+
+	if err := putEvidence(ctx, evidence); err != nil {
+		return err
+	}
+	if err := addToCaseIndex(ctx, caseID, evidenceID); err != nil {
+		return err
+	}
+	return clearReservation(ctx, caseID, evidenceID)
+}
+
+// GetEvidence returns the full evidence record, including its custody
+// events. While the record is embargoed (see SetEmbargo), a caller without
+// the embargo-override role attribute instead gets a minimal stub.
+func (s *SmartContract) GetEvidence(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) (*Evidence, error) {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return nil, err
+	}
+	embargoed, err := isEmbargoed(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+	if embargoed && requireEmbargoOverride(ctx) != nil {
+		return embargoedStub(e), nil
+	}
+	return e, nil
+}
+
+// GetEvidenceSummary returns the lightweight projection of an evidence record.
+func (s *SmartContract) GetEvidenceSummary(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) (*EvidenceSummary, error) {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return nil, err
+	}
+	return toSummary(e), nil
+}
+
+// evidenceIDPair identifies one evidence record for GetEvidenceSummaries.
+type evidenceIDPair struct {
+	CaseID     string `json:"caseID"`
+	EvidenceID string `json:"evidenceID"`
+}
+
+// GetEvidenceSummaries returns summaries for a batch of evidence records in
+// one call, given a JSON array of {caseID, evidenceID} pairs. Results are
+// returned in the same order as the request, with a nil entry for any pair
+// that doesn't resolve, so one missing row doesn't fail the whole batch.
+func (s *SmartContract) GetEvidenceSummaries(ctx contractapi.TransactionContextInterface, idPairsJSON string) ([]*EvidenceSummary, error) {
+	var pairs []evidenceIDPair
+	if err := json.Unmarshal([]byte(idPairsJSON), &pairs); err != nil {
+		return nil, fmt.Errorf("invalid idPairs JSON: %v", err)
+	}
+
+	summaries := make([]*EvidenceSummary, len(pairs))
+	for i, pair := range pairs {
+		e, err := getEvidence(ctx, pair.CaseID, pair.EvidenceID)
+		if err != nil {
+			summaries[i] = nil
+			continue
+		}
+		summaries[i] = toSummary(e)
+	}
+	return summaries, nil
+}
+
+// TransferCustody moves an evidence item to a new custodian, appending a
+// TRANSFER event. When custodian validation is enabled (see
+// SetRequireRegisteredCustodian), the recipient must be a registered,
+// active custodian. witnessID names who was physically present to co-sign
+// the handoff; it's required once SetRequireWitness(true) is set.
+func (s *SmartContract) TransferCustody(ctx contractapi.TransactionContextInterface, caseID, evidenceID, newCustodian, reason, examinerID, credentialRef, witnessID string) error {
+	return s.transferCustody(ctx, caseID, evidenceID, newCustodian, reason, nil, examinerID, credentialRef, witnessID, false)
+}
+
+// TransferCustodyOverridingWhitelist is TransferCustody for the one case a
+// sensitive case's custodian whitelist (see SetCaseCustodianWhitelist) needs
+// to be bypassed, e.g. an emergency handoff to an unvetted custodian.
+// Supervisor-only; the override is recorded on the resulting TRANSFER event.
+func (s *SmartContract) TransferCustodyOverridingWhitelist(ctx contractapi.TransactionContextInterface, caseID, evidenceID, newCustodian, reason, examinerID, credentialRef, witnessID string) error {
+	if err := requireSupervisor(ctx); err != nil {
+		return err
+	}
+	return s.transferCustody(ctx, caseID, evidenceID, newCustodian, reason, nil, examinerID, credentialRef, witnessID, true)
+}
+
+// transferCustody is the shared implementation behind TransferCustody and
+// TransferCustodyWithLocation, optionally recording where the handoff took
+// place. overrideWhitelist skips the case custodian whitelist check, for
+// TransferCustodyOverridingWhitelist's supervisor break-glass path.
+func (s *SmartContract) transferCustody(ctx contractapi.TransactionContextInterface, caseID, evidenceID, newCustodian, reason string, location *Location, examinerID, credentialRef, witnessID string, overrideWhitelist bool) error {
+	e, _, err := s.buildTransferCustody(ctx, caseID, evidenceID, newCustodian, reason, location, examinerID, credentialRef, witnessID, overrideWhitelist)
+	if err != nil {
+		return err
+	}
+	if overrideWhitelist {
+		e.Events[len(e.Events)-1].Reason = fmt.Sprintf("%s (custodian whitelist overridden by supervisor)", e.Events[len(e.Events)-1].Reason)
+	}
+	return putEvidence(ctx, e)
+}
+
+// buildTransferCustody validates a transfer and returns the evidence record
+// and custody event it would produce, without writing anything. It backs
+// both transferCustody and SimulateTransfer.
+func (s *SmartContract) buildTransferCustody(ctx contractapi.TransactionContextInterface, caseID, evidenceID, newCustodian, reason string, location *Location, examinerID, credentialRef, witnessID string, overrideWhitelist bool) (*Evidence, CustodyEvent, error) {
+	if err := validateID("newCustodian", newCustodian); err != nil {
+		return nil, CustodyEvent{}, err
+	}
+	if err := s.validateExaminer(ctx, examinerID, credentialRef); err != nil {
+		return nil, CustodyEvent{}, err
+	}
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return nil, CustodyEvent{}, err
+	}
+	if err := validateTransition(e, "transfer", []string{StatusActive}, "reactivate it first if it was archived, or resolve any invalidation"); err != nil {
+		return nil, CustodyEvent{}, err
+	}
+	if e.Disputed {
+		return nil, CustodyEvent{}, fmt.Errorf("cannot transfer evidence %s: it is frozen pending dispute resolution (%s)", evidenceID, e.DisputeRef)
+	}
+	if e.CheckedOut {
+		return nil, CustodyEvent{}, fmt.Errorf("cannot transfer evidence %s: it is checked out for examination by %s", evidenceID, e.CheckedOutBy)
+	}
+	if err := requireNotLockedForProcessing(e); err != nil {
+		return nil, CustodyEvent{}, err
+	}
+	if err := requireNotEmbargoed(ctx, e); err != nil {
+		return nil, CustodyEvent{}, err
+	}
+	if !overrideWhitelist {
+		if err := s.checkCustodianWhitelist(ctx, caseID, newCustodian); err != nil {
+			return nil, CustodyEvent{}, err
+		}
+	}
+
+	required, err := s.requiresRegisteredCustodian(ctx)
+	if err != nil {
+		return nil, CustodyEvent{}, err
+	}
+	if required {
+		custodian, err := s.GetCustodian(ctx, newCustodian)
+		if err != nil {
+			return nil, CustodyEvent{}, fmt.Errorf("transfer rejected: %v", err)
+		}
+		if !custodian.Active {
+			return nil, CustodyEvent{}, fmt.Errorf("transfer rejected: custodian %s is deregistered", newCustodian)
+		}
+	}
+
+	if err := s.checkEventCap(ctx, e, EventTransfer); err != nil {
+		return nil, CustodyEvent{}, err
+	}
+
+	previousOwner := e.CurrentOwner
+	witnessRequired, err := s.requiresWitness(ctx)
+	if err != nil {
+		return nil, CustodyEvent{}, err
+	}
+	if witnessRequired && witnessID == "" {
+		return nil, CustodyEvent{}, fmt.Errorf("transfer rejected: a witness is required")
+	}
+	if witnessID != "" {
+		if witnessID == previousOwner || witnessID == newCustodian {
+			return nil, CustodyEvent{}, fmt.Errorf("witness must be different from both the giver and receiver")
+		}
+	}
+
+	event, err := newCustodyEvent(ctx, EventTransfer, previousOwner, previousOwner, newCustodian, reason)
+	if err != nil {
+		return nil, CustodyEvent{}, err
+	}
+	event.Location = location
+	event.ExaminerID = examinerID
+	event.CredentialRef = credentialRef
+	event.WitnessID = witnessID
+
+	e.CurrentOwner = newCustodian
+	e.TransferCount++
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return e, event, nil
+}
+
+// TransferCustodyWithLocation is TransferCustody plus the geographic point
+// where the field handoff took place, for our mobile collection app.
+// latitude must be within [-90, 90] and longitude within [-180, 180].
+func (s *SmartContract) TransferCustodyWithLocation(ctx contractapi.TransactionContextInterface, caseID, evidenceID, newCustodian, reason string, latitude, longitude float64, locationName, examinerID, credentialRef, witnessID string) error {
+	if latitude < -90 || latitude > 90 {
+		return fmt.Errorf("latitude must be between -90 and 90, got %f", latitude)
+	}
+	if longitude < -180 || longitude > 180 {
+		return fmt.Errorf("longitude must be between -180 and 180, got %f", longitude)
+	}
+	location := &Location{Latitude: latitude, Longitude: longitude, LocationName: locationName}
+	return s.transferCustody(ctx, caseID, evidenceID, newCustodian, reason, location, examinerID, credentialRef, witnessID, false)
+}
+
+// GetEvidenceLocationHistory returns the sequence of locations recorded on
+// an evidence item's custody events, in chronological order.
+func (s *SmartContract) GetEvidenceLocationHistory(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) ([]*Location, error) {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return nil, err
+	}
+	var locations []*Location
+	for _, event := range e.Events {
+		if event.Location != nil {
+			locations = append(locations, event.Location)
+		}
+	}
+	return locations, nil
+}
+
+// buildInvalidateEvidence validates an invalidation and returns the evidence
+// record and custody event it would produce, without writing anything. It
+// backs both InvalidateEvidence and SimulateInvalidate.
+func (s *SmartContract) buildInvalidateEvidence(ctx contractapi.TransactionContextInterface, caseID, evidenceID, reasonCode, detail string) (*Evidence, CustodyEvent, error) {
+	if err := s.validateReasonCode(ctx, EventInvalidate, reasonCode); err != nil {
+		return nil, CustodyEvent{}, err
+	}
+	if err := s.validateReasonLength(ctx, "detail", detail); err != nil {
+		return nil, CustodyEvent{}, err
+	}
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return nil, CustodyEvent{}, err
+	}
+	if err := validateTransition(e, "invalidate", []string{StatusActive, StatusArchived, StatusQuarantined}, "evidence already invalidated cannot be invalidated again"); err != nil {
+		return nil, CustodyEvent{}, err
+	}
+	if err := s.enforceInvalidationWindow(ctx, e); err != nil {
+		return nil, CustodyEvent{}, err
+	}
+	if err := requireNotLockedForProcessing(e); err != nil {
+		return nil, CustodyEvent{}, err
+	}
+
+	event, err := newCustodyEvent(ctx, EventInvalidate, e.CurrentOwner, "", "", detail)
+	if err != nil {
+		return nil, CustodyEvent{}, err
+	}
+	event.ReasonCode = reasonCode
+
+	e.Status = StatusInvalidated
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return e, event, nil
+}
+
+// InvalidateEvidence marks an evidence item as invalidated, recording a
+// standardized reasonCode (see SetReasonCodes) plus free-text detail on an
+// INVALIDATE event. Invalidation is terminal. When cascade is true, every
+// item derived from this one (see RecordDerivedEvidence), transitively, is
+// also invalidated as suspect. Returns every evidenceID actually
+// invalidated by the call, including evidenceID itself.
+func (s *SmartContract) InvalidateEvidence(ctx contractapi.TransactionContextInterface, caseID, evidenceID, reasonCode, detail string, cascade bool) ([]string, error) {
+	e, event, err := s.buildInvalidateEvidence(ctx, caseID, evidenceID, reasonCode, detail)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := putEvidence(ctx, e); err != nil {
+		return nil, err
+	}
+
+	if err := writeInvalidationRecord(ctx, caseID, evidenceID, event.TxID, event.Timestamp, reasonCode, detail); err != nil {
+		return nil, err
+	}
+
+	affected := []string{evidenceID}
+	if cascade {
+		descendants, err := s.cascadeInvalidate(ctx, caseID, e.DerivedEvidenceIDs, reasonCode, evidenceID)
+		if err != nil {
+			return affected, err
+		}
+		affected = append(affected, descendants...)
+	}
+	return affected, nil
+}
+
+// cascadeInvalidate invalidates every evidenceID in derivedIDs that isn't
+// already invalidated, recording that it was cascade-invalidated from
+// parentID, then recurses into each one's own descendants.
+func (s *SmartContract) cascadeInvalidate(ctx contractapi.TransactionContextInterface, caseID string, derivedIDs []string, reasonCode, parentID string) ([]string, error) {
+	var affected []string
+	for _, evidenceID := range derivedIDs {
+		child, err := getEvidence(ctx, caseID, evidenceID)
+		if err != nil {
+			return affected, err
+		}
+		if child.Status == StatusInvalidated {
+			continue
+		}
+		detail := fmt.Sprintf("cascade-invalidated: derived from %s, which was invalidated", parentID)
+		e, event, err := s.buildInvalidateEvidence(ctx, caseID, evidenceID, reasonCode, detail)
+		if err != nil {
+			return affected, err
+		}
+		if err := putEvidence(ctx, e); err != nil {
+			return affected, err
+		}
+		if err := writeInvalidationRecord(ctx, caseID, evidenceID, event.TxID, event.Timestamp, reasonCode, detail); err != nil {
+			return affected, err
+		}
+		affected = append(affected, evidenceID)
+
+		descendants, err := s.cascadeInvalidate(ctx, caseID, e.DerivedEvidenceIDs, reasonCode, evidenceID)
+		if err != nil {
+			return affected, err
+		}
+		affected = append(affected, descendants...)
+	}
+	return affected, nil
+}
+
+// writeInvalidationRecord persists the standalone audit trail entry
+// alongside an INVALIDATE event; see InvalidationRecord.
+func writeInvalidationRecord(ctx contractapi.TransactionContextInterface, caseID, evidenceID, txID, timestamp, reasonCode, detail string) error {
+	record := &InvalidationRecord{
+		DocType:    "invalidation",
+		CaseID:     caseID,
+		EvidenceID: evidenceID,
+		TxID:       txID,
+		Timestamp:  timestamp,
+		ReasonCode: reasonCode,
+		Detail:     detail,
+	}
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal invalidation record: %v", err)
+	}
+	return ctx.GetStub().PutState(invalidationKey(caseID, evidenceID, txID), recordBytes)
+}
+
+// ArchiveToCold moves active evidence into the archived state, recording a
+// standardized reasonCode (see SetReasonCodes) plus free-text detail.
+func (s *SmartContract) ArchiveToCold(ctx contractapi.TransactionContextInterface, caseID, evidenceID, reasonCode, detail string) error {
+	if err := s.validateReasonCode(ctx, EventArchive, reasonCode); err != nil {
+		return err
+	}
+	if err := s.validateReasonLength(ctx, "detail", detail); err != nil {
+		return err
+	}
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if err := validateTransition(e, "archive", []string{StatusActive}, "it is likely already archived or invalidated; reactivate it first if it was archived"); err != nil {
+		return err
+	}
+	if e.Disputed {
+		return fmt.Errorf("cannot archive evidence %s: it is frozen pending dispute resolution (%s)", evidenceID, e.DisputeRef)
+	}
+	if e.CheckedOut {
+		return fmt.Errorf("cannot archive evidence %s: it is checked out for examination by %s", evidenceID, e.CheckedOutBy)
+	}
+	if err := requireNotLockedForProcessing(e); err != nil {
+		return err
+	}
+	if err := requireNotEmbargoed(ctx, e); err != nil {
+		return err
+	}
+	if err := s.checkEventCap(ctx, e, EventArchive); err != nil {
+		return err
+	}
+
+	event, err := newCustodyEvent(ctx, EventArchive, e.CurrentOwner, "", "", detail)
+	if err != nil {
+		return err
+	}
+	event.ReasonCode = reasonCode
+
+	e.Status = StatusArchived
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return putEvidence(ctx, e)
+}
+
+// ReactivateFromCold brings archived evidence back to active status.
+func (s *SmartContract) ReactivateFromCold(ctx contractapi.TransactionContextInterface, caseID, evidenceID, reason string) error {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if err := validateTransition(e, "reactivate", []string{StatusArchived}, "only archived evidence can be reactivated"); err != nil {
+		return err
+	}
+	if err := s.checkEventCap(ctx, e, EventReactivate); err != nil {
+		return err
+	}
+	if err := s.validateReasonLength(ctx, "reason", reason); err != nil {
+		return err
+	}
+
+	approvalRequired, err := s.reactivationRequiresApproval(ctx)
+	if err != nil {
+		return err
+	}
+	if approvalRequired {
+		return s.requestReactivation(ctx, e, reason)
+	}
+
+	event, err := newCustodyEvent(ctx, EventReactivate, e.CurrentOwner, "", "", reason)
+	if err != nil {
+		return err
+	}
+
+	e.Status = StatusActive
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return putEvidence(ctx, e)
+}
+
+// TransferAndArchive hands evidence directly to a storage custodian and
+// archives it in the same transaction, so clients never observe a
+// transferred-but-not-yet-archived window. It requires the same starting
+// state as TransferCustody (ACTIVE), rejects items that are checked out,
+// locked for processing or embargoed, appends a TRANSFER event followed by an
+// ARCHIVE event, and emits a single EvidenceHandedToStorage event to mirror
+// the combined physical handoff.
+func (s *SmartContract) TransferAndArchive(ctx contractapi.TransactionContextInterface, caseID, evidenceID, storageCustodian, reason string) error {
+	if err := validateID("storageCustodian", storageCustodian); err != nil {
+		return err
+	}
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if err := validateTransition(e, "transfer and archive", []string{StatusActive}, "reactivate it first if it was archived, or resolve any invalidation"); err != nil {
+		return err
+	}
+	if e.Disputed {
+		return fmt.Errorf("cannot transfer and archive evidence %s: it is frozen pending dispute resolution (%s)", evidenceID, e.DisputeRef)
+	}
+	if e.CheckedOut {
+		return fmt.Errorf("cannot transfer and archive evidence %s: it is checked out for examination by %s", evidenceID, e.CheckedOutBy)
+	}
+	if err := requireNotLockedForProcessing(e); err != nil {
+		return err
+	}
+	if err := requireNotEmbargoed(ctx, e); err != nil {
+		return err
+	}
+
+	required, err := s.requiresRegisteredCustodian(ctx)
+	if err != nil {
+		return err
+	}
+	if required {
+		custodian, err := s.GetCustodian(ctx, storageCustodian)
+		if err != nil {
+			return fmt.Errorf("transfer rejected: %v", err)
+		}
+		if !custodian.Active {
+			return fmt.Errorf("transfer rejected: custodian %s is deregistered", storageCustodian)
+		}
+	}
+	if err := s.checkEventCap(ctx, e, EventTransfer); err != nil {
+		return err
+	}
+	if err := s.checkEventCap(ctx, e, EventArchive); err != nil {
+		return err
+	}
+
+	previousOwner := e.CurrentOwner
+	transferEvent, err := newCustodyEvent(ctx, EventTransfer, previousOwner, previousOwner, storageCustodian, reason)
+	if err != nil {
+		return err
+	}
+	archiveEvent, err := newCustodyEvent(ctx, EventArchive, storageCustodian, "", "", reason)
+	if err != nil {
+		return err
+	}
+
+	e.CurrentOwner = storageCustodian
+	e.TransferCount++
+	e.Status = StatusArchived
+	e.UpdatedAt = archiveEvent.Timestamp
+	e.Events = append(e.Events, transferEvent, archiveEvent)
+
+	if err := putEvidence(ctx, e); err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(map[string]string{"caseID": caseID, "evidenceID": evidenceID, "storageCustodian": storageCustodian})
+	if err != nil {
+		return fmt.Errorf("failed to marshal EvidenceHandedToStorage payload: %v", err)
+	}
+	if err := ctx.GetStub().SetEvent("EvidenceHandedToStorage", payload); err != nil {
+		return fmt.Errorf("failed to emit EvidenceHandedToStorage event: %v", err)
+	}
+
+	return nil
+}
+
+// GetCustodyChain returns the ordered custody events stored on an evidence
+// item. Events moved out by CompactEventHistory are not included;
+// GetFullCustodyChain returns those as well.
+func (s *SmartContract) GetCustodyChain(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) ([]CustodyEvent, error) {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return nil, err
+	}
+	return e.Events, nil
+}
+
+// GetCustodyChains is the batch form of GetCustodyChain, fetching every
+// chain within caseID for evidenceIDs in a single transaction so report
+// generation doesn't pay one round trip per item. An evidenceID that
+// doesn't exist maps to an empty slice rather than failing the whole call.
+func (s *SmartContract) GetCustodyChains(ctx contractapi.TransactionContextInterface, caseID, evidenceIDsJSON string) (map[string][]CustodyEvent, error) {
+	var evidenceIDs []string
+	if err := json.Unmarshal([]byte(evidenceIDsJSON), &evidenceIDs); err != nil {
+		return nil, fmt.Errorf("invalid evidenceIDs JSON: %v", err)
+	}
+
+	chains := make(map[string][]CustodyEvent, len(evidenceIDs))
+	for _, evidenceID := range evidenceIDs {
+		e, err := getEvidence(ctx, caseID, evidenceID)
+		if err != nil {
+			chains[evidenceID] = []CustodyEvent{}
+			continue
+		}
+		chains[evidenceID] = e.Events
+	}
+	return chains, nil
+}
+
+// GetCustodyChainPaginated returns a window of an evidence item's custody
+// events, plus the total event count, so UIs with very long custody chains
+// don't have to pull every event (and risk the gRPC max message size) in
+// one call.
+func (s *SmartContract) GetCustodyChainPaginated(ctx contractapi.TransactionContextInterface, caseID, evidenceID string, offset, limit int) ([]CustodyEvent, int, error) {
+	if offset < 0 {
+		return nil, 0, fmt.Errorf("offset must be non-negative")
+	}
+	if limit <= 0 {
+		return nil, 0, fmt.Errorf("limit must be positive")
+	}
+
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total := len(e.Events)
+	if offset >= total {
+		return []CustodyEvent{}, total, nil
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return e.Events[offset:end], total, nil
+}
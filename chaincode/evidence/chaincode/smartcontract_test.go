@@ -0,0 +1,63 @@
+package chaincode
+
+import "testing"
+
+func TestEvidenceExists_NotFoundIsFalseNotError(t *testing.T) {
+	ctx := newMockCtx()
+	sc := &SmartContract{}
+
+	exists, err := sc.EvidenceExists(ctx, "case-1", "ev-1")
+	if err != nil {
+		t.Fatalf("expected no error for a missing record, got %v", err)
+	}
+	if exists {
+		t.Fatalf("expected EvidenceExists to report false for a missing record")
+	}
+}
+
+func TestGetEvidence_NotFoundReturnsTypedError(t *testing.T) {
+	ctx := newMockCtx()
+	sc := &SmartContract{}
+
+	_, err := sc.GetEvidence(ctx, "case-1", "ev-1")
+	if err == nil {
+		t.Fatalf("expected an error for a missing record")
+	}
+	if !isNotFoundError(err) {
+		t.Fatalf("expected a notFoundError, got %T: %v", err, err)
+	}
+}
+
+func TestCreateEvidence_ThenExistsAndGetAgree(t *testing.T) {
+	ctx := newMockCtx()
+	sc := &SmartContract{}
+
+	if err := sc.CreateEvidence(ctx, "case-1", "ev-1", "1111111111111111111111111111111111111111111111111111111111111111", "cid", "", "alice", "application/pdf", "", "", "", "", "", "", "", "", "", ""); err != nil {
+		t.Fatalf("CreateEvidence failed: %v", err)
+	}
+
+	exists, err := sc.EvidenceExists(ctx, "case-1", "ev-1")
+	if err != nil || !exists {
+		t.Fatalf("expected EvidenceExists to report true, got exists=%v err=%v", exists, err)
+	}
+
+	e, err := sc.GetEvidence(ctx, "case-1", "ev-1")
+	if err != nil {
+		t.Fatalf("GetEvidence failed after create: %v", err)
+	}
+	if e.Status != StatusActive {
+		t.Fatalf("expected newly created evidence to be ACTIVE, got %s", e.Status)
+	}
+}
+
+func TestCreateEvidence_DuplicateRejected(t *testing.T) {
+	ctx := newMockCtx()
+	sc := &SmartContract{}
+
+	if err := sc.CreateEvidence(ctx, "case-1", "ev-1", "1111111111111111111111111111111111111111111111111111111111111111", "cid", "", "alice", "application/pdf", "", "", "", "", "", "", "", "", "", ""); err != nil {
+		t.Fatalf("CreateEvidence failed: %v", err)
+	}
+	if err := sc.CreateEvidence(ctx, "case-1", "ev-1", "1111111111111111111111111111111111111111111111111111111111111111", "cid", "", "alice", "application/pdf", "", "", "", "", "", "", "", "", "", ""); err == nil {
+		t.Fatalf("expected duplicate CreateEvidence to be rejected")
+	}
+}
@@ -0,0 +1,73 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// SplitCase opens newCaseID (if it doesn't already exist) and cross-references
+// each listed evidenceID, which must already belong to sourceCaseID, onto it
+// via LinkedCaseIDs, without duplicating the underlying evidence record. This
+// models a case splitting, e.g. co-defendants being severed into their own
+// proceeding that still needs to cite shared evidence. A CASE_SPLIT custody
+// event is recorded on every linked item.
+func (s *SmartContract) SplitCase(ctx contractapi.TransactionContextInterface, sourceCaseID, newCaseID, evidenceIDsJSON string) error {
+	if err := validateID("sourceCaseID", sourceCaseID); err != nil {
+		return err
+	}
+	if err := validateID("newCaseID", newCaseID); err != nil {
+		return err
+	}
+	if sourceCaseID == newCaseID {
+		return fmt.Errorf("newCaseID must differ from sourceCaseID")
+	}
+
+	var evidenceIDs []string
+	if err := json.Unmarshal([]byte(evidenceIDsJSON), &evidenceIDs); err != nil {
+		return fmt.Errorf("invalid evidenceIDs JSON: %v", err)
+	}
+	if len(evidenceIDs) == 0 {
+		return fmt.Errorf("evidenceIDs must not be empty")
+	}
+
+	newCase, err := getOrCreateCase(ctx, newCaseID)
+	if err != nil {
+		return err
+	}
+	if err := putCase(ctx, newCase); err != nil {
+		return err
+	}
+
+	for _, evidenceID := range evidenceIDs {
+		e, err := getEvidence(ctx, sourceCaseID, evidenceID)
+		if err != nil {
+			return fmt.Errorf("evidence %s must already belong to case %s: %v", evidenceID, sourceCaseID, err)
+		}
+
+		alreadyLinked := false
+		for _, linked := range e.LinkedCaseIDs {
+			if linked == newCaseID {
+				alreadyLinked = true
+				break
+			}
+		}
+		if alreadyLinked {
+			continue
+		}
+
+		event, err := newCustodyEvent(ctx, EventCaseSplit, e.CurrentOwner, "", "", fmt.Sprintf("cross-referenced onto split case %s", newCaseID))
+		if err != nil {
+			return err
+		}
+		e.LinkedCaseIDs = append(e.LinkedCaseIDs, newCaseID)
+		e.UpdatedAt = event.Timestamp
+		e.Events = append(e.Events, event)
+		if err := putEvidence(ctx, e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// stateDBTypeKey stores which state database backend this channel's peers
+// run, so rich-query functions can avoid the opaque failure GetQueryResult
+// produces on a LevelDB-backed peer (which doesn't support CouchDB selector
+// syntax at all). Defaults to "couchdb" when unset, since that's what every
+// rich-query function here was originally written against.
+const stateDBTypeKey = "POLICY_STATE_DB_TYPE"
+
+// SetStateDBType records which state database backend this channel's peers
+// run: "couchdb" or "leveldb". Operators should set this once at network
+// setup if running LevelDB, so rich-query functions fall back to a range
+// scan instead of failing unpredictably.
+func (s *SmartContract) SetStateDBType(ctx contractapi.TransactionContextInterface, dbType string) error {
+	if dbType != "couchdb" && dbType != "leveldb" {
+		return fmt.Errorf("dbType must be 'couchdb' or 'leveldb', got %q", dbType)
+	}
+	return ctx.GetStub().PutState(stateDBTypeKey, []byte(dbType))
+}
+
+func getStateDBType(ctx contractapi.TransactionContextInterface) (string, error) {
+	bytes, err := ctx.GetStub().GetState(stateDBTypeKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to read state database type: %v", err)
+	}
+	if len(bytes) == 0 {
+		return "couchdb", nil
+	}
+	return string(bytes), nil
+}
+
+// scanAllEvidenceWithSelector range-scans every evidence record (the same
+// approach examiner.go and witness.go use for their own queries) and keeps
+// only the ones matching selector, for use when the peer's state database
+// doesn't support CouchDB rich queries. It understands the selector shapes
+// this chaincode's own query functions actually build: plain equality,
+// $in, $gte/$lte, and $elemMatch.
+func scanAllEvidenceWithSelector(ctx contractapi.TransactionContextInterface, selector map[string]interface{}) ([]*Evidence, error) {
+	iterator, err := ctx.GetStub().GetStateByRange(evidenceKeyPrefix, evidenceKeyPrefix+"￿")
+	if err != nil {
+		return nil, fmt.Errorf("failed to range-scan evidence: %v", err)
+	}
+	defer iterator.Close()
+
+	var results []*Evidence
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		var e Evidence
+		if err := json.Unmarshal(item.Value, &e); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal evidence from range scan: %v", err)
+		}
+
+		var asMap map[string]interface{}
+		fieldBytes, err := json.Marshal(&e)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal evidence for selector matching: %v", err)
+		}
+		if err := json.Unmarshal(fieldBytes, &asMap); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal evidence for selector matching: %v", err)
+		}
+		asMap["docType"] = "evidence"
+
+		matches, err := matchesSelector(asMap, selector)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			results = append(results, &e)
+		}
+	}
+	sortEvidences(results)
+	return results, nil
+}
+
+// matchesSelector evaluates a (small, known) subset of CouchDB selector
+// syntax against doc, a JSON-decoded evidence record. Unsupported operators
+// return an error rather than silently matching everything.
+func matchesSelector(doc map[string]interface{}, selector map[string]interface{}) (bool, error) {
+	for field, want := range selector {
+		got := doc[field]
+		ops, isOps := want.(map[string]interface{})
+		if !isOps {
+			if !valuesEqual(got, want) {
+				return false, nil
+			}
+			continue
+		}
+		for op, arg := range ops {
+			ok, err := evalOperator(got, op, arg)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+func evalOperator(got interface{}, op string, arg interface{}) (bool, error) {
+	switch op {
+	case "$gte":
+		return compareStrings(got, arg) >= 0, nil
+	case "$lte":
+		return compareStrings(got, arg) <= 0, nil
+	case "$in":
+		list, ok := arg.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("$in requires a list argument")
+		}
+		for _, candidate := range list {
+			if valuesEqual(got, candidate) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "$elemMatch":
+		subSelector, ok := arg.(map[string]interface{})
+		if !ok {
+			return false, fmt.Errorf("$elemMatch requires an object argument")
+		}
+		items, _ := got.([]interface{})
+		for _, item := range items {
+			itemMap, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			matches, err := matchesSelector(itemMap, subSelector)
+			if err != nil {
+				return false, err
+			}
+			if matches {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return false, fmt.Errorf("unsupported selector operator %q in LevelDB fallback mode", op)
+	}
+}
+
+func valuesEqual(a, b interface{}) bool {
+	return fmt.Sprintf("%v", a) == fmt.Sprintf("%v", b)
+}
+
+func compareStrings(a, b interface{}) int {
+	as := fmt.Sprintf("%v", a)
+	bs := fmt.Sprintf("%v", b)
+	switch {
+	case as < bs:
+		return -1
+	case as > bs:
+		return 1
+	default:
+		return 0
+	}
+}
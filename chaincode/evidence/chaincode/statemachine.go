@@ -0,0 +1,39 @@
+package chaincode
+
+import "fmt"
+
+// transitionError is returned when an operation is attempted from a status
+// that doesn't allow it. It carries enough detail for a caller-facing
+// message that tells the investigator not just what's wrong but how to fix
+// it, instead of a bare "invalid status" string.
+type transitionError struct {
+	evidenceID  string
+	operation   string
+	current     string
+	allowed     []string
+	remediation string
+}
+
+func (e *transitionError) Error() string {
+	return fmt.Sprintf("cannot %s evidence %s: status is %s, requires %v - %s",
+		e.operation, e.evidenceID, e.current, e.allowed, e.remediation)
+}
+
+// validateTransition reports a transitionError unless e.Status is one of
+// allowed, centralizing the "current status / required status / remediation"
+// shape so every mutating operation reports precondition failures the same
+// way.
+func validateTransition(e *Evidence, operation string, allowed []string, remediation string) error {
+	for _, status := range allowed {
+		if e.Status == status {
+			return nil
+		}
+	}
+	return &transitionError{
+		evidenceID:  e.EvidenceID,
+		operation:   operation,
+		current:     e.Status,
+		allowed:     allowed,
+		remediation: remediation,
+	}
+}
@@ -0,0 +1,85 @@
+package chaincode
+
+import "testing"
+
+func mustCreate(t *testing.T, ctx *mockCtx, sc *SmartContract, caseID, evidenceID string) {
+	t.Helper()
+	if err := sc.CreateEvidence(ctx, caseID, evidenceID, "1111111111111111111111111111111111111111111111111111111111111111", "cid", "", "alice", "application/pdf", "", "", "", "", "", "", "", "", "", ""); err != nil {
+		t.Fatalf("CreateEvidence failed: %v", err)
+	}
+}
+
+func TestTransferCustody_RejectsArchivedEvidence(t *testing.T) {
+	ctx := newMockCtx()
+	sc := &SmartContract{}
+	mustCreate(t, ctx, sc, "case-1", "ev-1")
+
+	if err := sc.ArchiveToCold(ctx, "case-1", "ev-1", "code", "detail-exceeds-minimum"); err != nil {
+		t.Fatalf("ArchiveToCold failed: %v", err)
+	}
+	if err := sc.TransferCustody(ctx, "case-1", "ev-1", "bob", "reason", "", "", ""); err == nil {
+		t.Fatalf("expected transfer of archived evidence to be rejected")
+	} else if !isTransitionError(err) {
+		t.Fatalf("expected a transitionError, got %T: %v", err, err)
+	}
+}
+
+func TestArchiveToCold_RejectsAlreadyArchivedEvidence(t *testing.T) {
+	ctx := newMockCtx()
+	sc := &SmartContract{}
+	mustCreate(t, ctx, sc, "case-1", "ev-1")
+
+	if err := sc.ArchiveToCold(ctx, "case-1", "ev-1", "code", "detail-exceeds-minimum"); err != nil {
+		t.Fatalf("ArchiveToCold failed: %v", err)
+	}
+	if err := sc.ArchiveToCold(ctx, "case-1", "ev-1", "code", "detail-exceeds-minimum"); err == nil {
+		t.Fatalf("expected re-archiving to be rejected")
+	} else if !isTransitionError(err) {
+		t.Fatalf("expected a transitionError, got %T: %v", err, err)
+	}
+}
+
+func TestReactivateFromCold_RejectsActiveEvidence(t *testing.T) {
+	ctx := newMockCtx()
+	sc := &SmartContract{}
+	mustCreate(t, ctx, sc, "case-1", "ev-1")
+
+	if err := sc.ReactivateFromCold(ctx, "case-1", "ev-1", "reason"); err == nil {
+		t.Fatalf("expected reactivating active evidence to be rejected")
+	} else if !isTransitionError(err) {
+		t.Fatalf("expected a transitionError, got %T: %v", err, err)
+	}
+}
+
+func TestInvalidateEvidence_RejectsDoubleInvalidation(t *testing.T) {
+	ctx := newMockCtx()
+	sc := &SmartContract{}
+	mustCreate(t, ctx, sc, "case-1", "ev-1")
+
+	if _, err := sc.InvalidateEvidence(ctx, "case-1", "ev-1", "code", "detail-exceeds-minimum", false); err != nil {
+		t.Fatalf("InvalidateEvidence failed: %v", err)
+	}
+	if _, err := sc.InvalidateEvidence(ctx, "case-1", "ev-1", "code", "detail-exceeds-minimum", false); err == nil {
+		t.Fatalf("expected double invalidation to be rejected")
+	} else if !isTransitionError(err) {
+		t.Fatalf("expected a transitionError, got %T: %v", err, err)
+	}
+}
+
+func TestTransferCustody_RejectsDisputedEvidence(t *testing.T) {
+	ctx := newMockCtx()
+	sc := &SmartContract{}
+	mustCreate(t, ctx, sc, "case-1", "ev-1")
+
+	if err := sc.MarkDisputed(ctx, "case-1", "ev-1", "motion-1"); err != nil {
+		t.Fatalf("MarkDisputed failed: %v", err)
+	}
+	if err := sc.TransferCustody(ctx, "case-1", "ev-1", "bob", "reason", "", "", ""); err == nil {
+		t.Fatalf("expected transfer of disputed evidence to be rejected")
+	}
+}
+
+func isTransitionError(err error) bool {
+	_, ok := err.(*transitionError)
+	return ok
+}
@@ -0,0 +1,50 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// RecordStorageMove logs that archived evidence physically moved between
+// cold storage facilities, without reactivating it. Only ARCHIVED evidence
+// may be moved; use ReactivateFromCold first if the item needs handling
+// rather than relocation.
+func (s *SmartContract) RecordStorageMove(ctx contractapi.TransactionContextInterface, caseID, evidenceID, fromLocation, toLocation, reason string) error {
+	if toLocation == "" {
+		return fmt.Errorf("toLocation is required")
+	}
+
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if err := validateTransition(e, "storage move", []string{StatusArchived}, "only archived evidence can be moved between storage facilities"); err != nil {
+		return err
+	}
+	if fromLocation != "" && e.StorageLocation != "" && fromLocation != e.StorageLocation {
+		return fmt.Errorf("evidence %s is recorded at %q, not %q", evidenceID, e.StorageLocation, fromLocation)
+	}
+
+	event, err := newCustodyEvent(ctx, EventStorageMove, e.CurrentOwner, "", "", reason)
+	if err != nil {
+		return err
+	}
+	event.Location = &Location{LocationName: toLocation}
+
+	e.StorageLocation = toLocation
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return putEvidence(ctx, e)
+}
+
+// GetStorageLocation returns the physical facility currently holding
+// archived evidence, as last set by RecordStorageMove.
+func (s *SmartContract) GetStorageLocation(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) (string, error) {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return "", err
+	}
+	return e.StorageLocation, nil
+}
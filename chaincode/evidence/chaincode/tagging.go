@@ -0,0 +1,61 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// AddTag attaches a free-form label to evidenceID for ad hoc curation (e.g.
+// marking items for a specific review pass) outside the formal
+// classification/status model. A no-op, reported as such by callers that
+// check first, if the tag is already present.
+func (s *SmartContract) AddTag(ctx contractapi.TransactionContextInterface, caseID, evidenceID, tag string) error {
+	if tag == "" {
+		return fmt.Errorf("tag must not be empty")
+	}
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	for _, existing := range e.Tags {
+		if existing == tag {
+			return nil
+		}
+	}
+
+	event, err := newCustodyEvent(ctx, EventTag, e.CurrentOwner, "", "", fmt.Sprintf("tagged %q", tag))
+	if err != nil {
+		return err
+	}
+
+	e.Tags = append(e.Tags, tag)
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return putEvidence(ctx, e)
+}
+
+// BulkAddTag resolves filterJSON (the same EvidenceFilter QueryEvidences
+// accepts) and adds tag to every matching item, skipping ones that already
+// carry it. One item failing doesn't abort the rest.
+func (s *SmartContract) BulkAddTag(ctx contractapi.TransactionContextInterface, filterJSON, tag string) (*BatchResult, error) {
+	if tag == "" {
+		return nil, fmt.Errorf("tag must not be empty")
+	}
+	summaries, err := s.QueryEvidences(ctx, filterJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newBatchResult()
+	for _, summary := range summaries {
+		key := summary.CaseID + "_" + summary.EvidenceID
+		if err := s.AddTag(ctx, summary.CaseID, summary.EvidenceID, tag); err != nil {
+			result.Failed[key] = err.Error()
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, key)
+	}
+	return result, nil
+}
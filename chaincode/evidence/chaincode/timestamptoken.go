@@ -0,0 +1,84 @@
+package chaincode
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// timestampTokenPrefix identifies the chaincode's expected encoding of an
+// RFC 3161 timestamp token: "tsa1:<base64 TSA response>:<embedded time,
+// RFC3339>". The chaincode never parses the TSA response itself (that
+// requires the TSA's certificate and belongs off-chain); it only checks the
+// envelope is well-formed and that the embedded time is plausible.
+const timestampTokenPrefix = "tsa1:"
+
+// timestampTokenTolerance is how far the token's embedded time may drift
+// from the evidence's CreatedAt before VerifyTimestampToken rejects it,
+// allowing for the off-chain round trip to the TSA before submission.
+const timestampTokenTolerance = 1 * time.Hour
+
+// validateTimestampTokenFormat checks that a non-empty timestampToken
+// parses as "tsa1:<base64>:<RFC3339>" without validating the TSA response
+// itself.
+func validateTimestampTokenFormat(token string) error {
+	_, _, err := parseTimestampToken(token)
+	return err
+}
+
+func parseTimestampToken(token string) (payload []byte, embeddedTime time.Time, err error) {
+	if !strings.HasPrefix(token, timestampTokenPrefix) {
+		return nil, time.Time{}, fmt.Errorf("timestampToken must start with %q", timestampTokenPrefix)
+	}
+	rest := strings.TrimPrefix(token, timestampTokenPrefix)
+	parts := strings.SplitN(rest, ":", 2)
+	if len(parts) != 2 {
+		return nil, time.Time{}, fmt.Errorf("timestampToken must have the form %q", timestampTokenPrefix+"<base64>:<RFC3339>")
+	}
+	payload, err = base64.StdEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("timestampToken payload is not valid base64: %v", err)
+	}
+	if len(payload) == 0 {
+		return nil, time.Time{}, fmt.Errorf("timestampToken payload is empty")
+	}
+	embeddedTime, err = time.Parse(time.RFC3339, parts[1])
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("timestampToken embedded time is not RFC3339: %v", err)
+	}
+	return payload, embeddedTime, nil
+}
+
+// VerifyTimestampToken sanity-checks evidenceID's stored TimestampToken: that
+// it's well-formed and that its embedded time falls within
+// timestampTokenTolerance of the evidence's CreatedAt. This is not a
+// cryptographic verification of the TSA's signature chain, which an
+// off-chain verifier must perform against the TSA's certificate.
+func (s *SmartContract) VerifyTimestampToken(ctx contractapi.TransactionContextInterface, caseID, evidenceID string) (bool, error) {
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return false, err
+	}
+	if e.TimestampToken == "" {
+		return false, fmt.Errorf("evidence %s has no timestampToken", evidenceID)
+	}
+
+	_, embeddedTime, err := parseTimestampToken(e.TimestampToken)
+	if err != nil {
+		return false, nil
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, e.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("corrupt CreatedAt on evidence %s: %v", e.EvidenceID, err)
+	}
+
+	drift := embeddedTime.Sub(createdAt)
+	if drift < 0 {
+		drift = -drift
+	}
+	return drift <= timestampTokenTolerance, nil
+}
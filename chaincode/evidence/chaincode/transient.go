@@ -0,0 +1,34 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// CreateEvidenceTransientHash creates evidence the same way CreateEvidence
+// does, except hash and metadata are read from the transaction's transient
+// field instead of from public arguments.
+//
+// Security tradeoff: transient data is carried only in the signed proposal
+// sent directly to the endorsing peers named in the transaction, not
+// gossiped across the channel or written into the ordering service's
+// blocks, so it avoids exposing a sensitive pre-image to every peer before
+// the transaction is ever committed. It is NOT equivalent to a private data
+// collection: once this function runs, the hash is written into the
+// regular Evidence record and becomes visible, like any other world-state
+// value, to every peer with read access to the channel. Use this only to
+// reduce pre-commit exposure, not to keep the hash confidential long-term.
+func (s *SmartContract) CreateEvidenceTransientHash(ctx contractapi.TransactionContextInterface, caseID, evidenceID, cid, custodianID, contentType string) error {
+	transient, err := ctx.GetStub().GetTransient()
+	if err != nil {
+		return fmt.Errorf("failed to read transient data: %v", err)
+	}
+	hashBytes, ok := transient["hash"]
+	if !ok || len(hashBytes) == 0 {
+		return fmt.Errorf("transient field %q is required", "hash")
+	}
+	metadataJSON := string(transient["metadata"])
+
+	return s.CreateEvidence(ctx, caseID, evidenceID, string(hashBytes), cid, metadataJSON, custodianID, contentType, "", "", "", "", "", "", "", "", "", "")
+}
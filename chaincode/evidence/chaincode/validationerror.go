@@ -0,0 +1,30 @@
+package chaincode
+
+import "encoding/json"
+
+// ValidationError is one field-level input problem, structured so the REST
+// gateway in front of this chaincode can return a machine-parseable HTTP
+// 400 body instead of string-matching a flat error message.
+type ValidationError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *ValidationError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors aggregates every input problem found in a single call,
+// so a caller gets the full set of fixes needed at once instead of fixing
+// one field, resubmitting, and hitting the next. Error() marshals the set
+// to a JSON array for the gateway to pass straight through as the response
+// body.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	bytes, err := json.Marshal(e)
+	if err != nil {
+		return "validation failed"
+	}
+	return string(bytes)
+}
@@ -0,0 +1,152 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// quarantineOnFailureKey toggles whether a failed integrity check
+// automatically moves evidence into QUARANTINED status.
+const quarantineOnFailureKey = "POLICY_QUARANTINE_ON_VERIFY_FAILURE"
+
+// SetQuarantineOnFailure enables or disables automatic quarantine when
+// VerifyEvidenceIntegrity detects a hash mismatch.
+func (s *SmartContract) SetQuarantineOnFailure(ctx contractapi.TransactionContextInterface, enabled bool) error {
+	value := "false"
+	if enabled {
+		value = "true"
+	}
+	return ctx.GetStub().PutState(quarantineOnFailureKey, []byte(value))
+}
+
+func (s *SmartContract) quarantineOnFailureEnabled(ctx contractapi.TransactionContextInterface) (bool, error) {
+	bytes, err := ctx.GetStub().GetState(quarantineOnFailureKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to read quarantine policy: %v", err)
+	}
+	return string(bytes) == "true", nil
+}
+
+// VerifyEvidenceIntegrity checks providedHash against the stored hash,
+// recording a VERIFY event either way. On mismatch, and if
+// SetQuarantineOnFailure is enabled, the evidence is moved to QUARANTINED
+// and an EvidenceQuarantined event is emitted to block further transfers
+// until a supervisor resolves it.
+func (s *SmartContract) VerifyEvidenceIntegrity(ctx contractapi.TransactionContextInterface, caseID, evidenceID, providedHash, examinerID, credentialRef string) (bool, error) {
+	if err := s.validateExaminer(ctx, examinerID, credentialRef); err != nil {
+		return false, err
+	}
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return false, err
+	}
+
+	algorithm := e.HashAlgorithm
+	if algorithm == "" {
+		algorithm = DefaultHashAlgorithm
+	}
+	if err := validateHashFormat(algorithm, providedHash); err != nil {
+		return false, fmt.Errorf("provided hash does not match evidence's recorded algorithm (%s): %v", algorithm, err)
+	}
+
+	matches := providedHash == e.Hash
+	for _, partHash := range e.PartHashes {
+		if providedHash == partHash {
+			matches = true
+			break
+		}
+	}
+	reason := "hash matched"
+	if !matches {
+		reason = "hash mismatch"
+	}
+
+	event, err := newCustodyEvent(ctx, EventVerify, e.CurrentOwner, "", "", reason)
+	if err != nil {
+		return false, err
+	}
+	event.ExaminerID = examinerID
+	event.CredentialRef = credentialRef
+	event.VerifyPassed = matches
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	if !matches {
+		quarantine, err := s.quarantineOnFailureEnabled(ctx)
+		if err != nil {
+			return false, err
+		}
+		if quarantine && e.Status == StatusActive {
+			e.Status = StatusQuarantined
+			if err := ctx.GetStub().SetEvent("EvidenceQuarantined", []byte(fmt.Sprintf(`{"caseID":%q,"evidenceID":%q}`, caseID, evidenceID))); err != nil {
+				return false, fmt.Errorf("failed to emit EvidenceQuarantined event: %v", err)
+			}
+		}
+	}
+
+	if err := putEvidence(ctx, e); err != nil {
+		return false, err
+	}
+
+	return matches, nil
+}
+
+// VerifyAndTransfer checks providedHash against the stored hash and only
+// proceeds with the custody transfer if it matches, so "verify before
+// handoff" happens as a single atomic operation instead of two transactions
+// a caller could forget to chain together. On mismatch the transfer is
+// aborted and the failed VerifyEvidenceIntegrity call's own event (and any
+// configured auto-quarantine) stands as the record of what happened.
+func (s *SmartContract) VerifyAndTransfer(ctx contractapi.TransactionContextInterface, caseID, evidenceID, newCustodian, providedHash, reason string) error {
+	matches, err := s.VerifyEvidenceIntegrity(ctx, caseID, evidenceID, providedHash, "", "")
+	if err != nil {
+		return err
+	}
+	if !matches {
+		return fmt.Errorf("cannot transfer evidence %s: provided hash does not match the stored hash", evidenceID)
+	}
+	return s.transferCustody(ctx, caseID, evidenceID, newCustodian, reason, nil, "", "", "", false)
+}
+
+// ResolveQuarantine moves a quarantined evidence item back to active (if
+// the tamper concern was unfounded) or to invalidated (if confirmed).
+// Supervisor-only.
+func (s *SmartContract) ResolveQuarantine(ctx contractapi.TransactionContextInterface, caseID, evidenceID, resolution string) error {
+	if err := requireSupervisor(ctx); err != nil {
+		return err
+	}
+
+	e, err := getEvidence(ctx, caseID, evidenceID)
+	if err != nil {
+		return err
+	}
+	if e.Status != StatusQuarantined {
+		return fmt.Errorf("evidence %s is not quarantined (status is %s)", evidenceID, e.Status)
+	}
+
+	var newStatus string
+	switch resolution {
+	case "active":
+		newStatus = StatusActive
+	case "invalidated":
+		newStatus = StatusInvalidated
+	default:
+		return fmt.Errorf("resolution must be 'active' or 'invalidated', got %q", resolution)
+	}
+
+	event, err := newCustodyEvent(ctx, EventInvalidate, e.CurrentOwner, "", "", fmt.Sprintf("quarantine resolved: %s", resolution))
+	if err != nil {
+		return err
+	}
+	if newStatus != StatusInvalidated {
+		event.EventType = EventVerify
+		event.Reason = fmt.Sprintf("quarantine resolved: %s", resolution)
+	}
+
+	e.Status = newStatus
+	e.UpdatedAt = event.Timestamp
+	e.Events = append(e.Events, event)
+
+	return putEvidence(ctx, e)
+}
@@ -0,0 +1,15 @@
+package chaincode
+
+import "github.com/hyperledger/fabric-contract-api-go/contractapi"
+
+// ChaincodeVersion identifies this build of the chaincode. Bump it whenever
+// a released change alters behavior an external-service client might rely
+// on, so GetVersion (and Ping) let a client assert the deployed binary
+// matches what it expects before submitting a transaction, catching a
+// stale-chaincode-vs-updated-client deployment mismatch early.
+const ChaincodeVersion = "1.0.0"
+
+// GetVersion returns the deployed chaincode's version string.
+func (s *SmartContract) GetVersion(ctx contractapi.TransactionContextInterface) (string, error) {
+	return ChaincodeVersion, nil
+}
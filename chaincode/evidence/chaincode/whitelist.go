@@ -0,0 +1,70 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// custodianWhitelistKeyPrefix namespaces per-case custodian whitelists, for
+// sensitive cases where evidence may only ever be held by specific vetted
+// custodians.
+const custodianWhitelistKeyPrefix = "CASE_CUSTODIAN_WHITELIST_"
+
+func custodianWhitelistKey(caseID string) string {
+	return custodianWhitelistKeyPrefix + caseID
+}
+
+// SetCaseCustodianWhitelist restricts caseID's evidence to only ever be
+// transferred to one of custodians. Passing an empty list removes the
+// restriction.
+func (s *SmartContract) SetCaseCustodianWhitelist(ctx contractapi.TransactionContextInterface, caseID, custodiansJSON string) error {
+	if err := validateID("caseID", caseID); err != nil {
+		return err
+	}
+	var custodians []string
+	if err := json.Unmarshal([]byte(custodiansJSON), &custodians); err != nil {
+		return fmt.Errorf("invalid custodians JSON: %v", err)
+	}
+	bytes, err := json.Marshal(custodians)
+	if err != nil {
+		return fmt.Errorf("failed to marshal custodian whitelist: %v", err)
+	}
+	return ctx.GetStub().PutState(custodianWhitelistKey(caseID), bytes)
+}
+
+// GetCaseCustodianWhitelist returns the configured custodian whitelist for
+// caseID, or nil if none is set.
+func (s *SmartContract) GetCaseCustodianWhitelist(ctx contractapi.TransactionContextInterface, caseID string) ([]string, error) {
+	bytes, err := ctx.GetStub().GetState(custodianWhitelistKey(caseID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custodian whitelist for case %s: %v", caseID, err)
+	}
+	if bytes == nil {
+		return nil, nil
+	}
+	var custodians []string
+	if err := json.Unmarshal(bytes, &custodians); err != nil {
+		return nil, fmt.Errorf("corrupt custodian whitelist for case %s: %v", caseID, err)
+	}
+	return custodians, nil
+}
+
+// checkCustodianWhitelist rejects a transfer to newCustodian when caseID has
+// a configured whitelist that doesn't include them.
+func (s *SmartContract) checkCustodianWhitelist(ctx contractapi.TransactionContextInterface, caseID, newCustodian string) error {
+	whitelist, err := s.GetCaseCustodianWhitelist(ctx, caseID)
+	if err != nil {
+		return err
+	}
+	if len(whitelist) == 0 {
+		return nil
+	}
+	for _, custodian := range whitelist {
+		if custodian == newCustodian {
+			return nil
+		}
+	}
+	return fmt.Errorf("custodian %s is not on case %s's approved custodian whitelist", newCustodian, caseID)
+}
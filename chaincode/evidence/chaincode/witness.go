@@ -0,0 +1,72 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// witnessPolicyKey stores whether TransferCustody rejects transfers missing
+// a witnessID.
+const witnessPolicyKey = "POLICY_REQUIRE_WITNESS"
+
+// SetRequireWitness toggles whether TransferCustody rejects transfers that
+// don't name a witness, digitizing the paper custody form's co-signature
+// requirement.
+func (s *SmartContract) SetRequireWitness(ctx contractapi.TransactionContextInterface, required bool) error {
+	value := "false"
+	if required {
+		value = "true"
+	}
+	return ctx.GetStub().PutState(witnessPolicyKey, []byte(value))
+}
+
+func (s *SmartContract) requiresWitness(ctx contractapi.TransactionContextInterface) (bool, error) {
+	bytes, err := ctx.GetStub().GetState(witnessPolicyKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to read witness policy: %v", err)
+	}
+	return string(bytes) == "true", nil
+}
+
+// QueryEventsByWitness lists every custody event a given witness co-signed,
+// across every case.
+func (s *SmartContract) QueryEventsByWitness(ctx contractapi.TransactionContextInterface, witnessID string) ([]AuditLogEntry, error) {
+	if err := validateID("witnessID", witnessID); err != nil {
+		return nil, err
+	}
+
+	iterator, err := ctx.GetStub().GetStateByRange(evidenceKeyPrefix, evidenceKeyPrefix+"￿")
+	if err != nil {
+		return nil, fmt.Errorf("failed to range over evidence: %v", err)
+	}
+	defer iterator.Close()
+
+	var entries []AuditLogEntry
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		var e Evidence
+		if err := json.Unmarshal(item.Value, &e); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal evidence: %v", err)
+		}
+		for _, event := range e.Events {
+			if event.WitnessID != witnessID {
+				continue
+			}
+			entries = append(entries, AuditLogEntry{
+				CaseID:       e.CaseID,
+				EvidenceID:   e.EvidenceID,
+				CustodyEvent: event,
+			})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Timestamp < entries[j].Timestamp
+	})
+	return entries, nil
+}
@@ -0,0 +1,22 @@
+// Package main starts the evidence custody chaincode as an external service,
+// backing the hot/cold chains that the JumpServer blockchain app talks to
+// via the Fabric SDK (see apps/blockchain/clients/fabric_client.py).
+package main
+
+import (
+	"log"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/jumpserver/evidence-chaincode/chaincode"
+)
+
+func main() {
+	cc, err := contractapi.NewChaincode(&chaincode.SmartContract{})
+	if err != nil {
+		log.Panicf("Error creating evidence custody chaincode: %v", err)
+	}
+
+	if err := cc.Start(); err != nil {
+		log.Panicf("Error starting evidence custody chaincode: %v", err)
+	}
+}